@@ -0,0 +1,41 @@
+package entropy
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Stats contains the strength of a generator configuration.
+type Stats struct {
+	// Keyspace is the exact number of distinct values that can be generated.
+	Keyspace *big.Int
+	// Bits is the entropy of the Keyspace in bits, i.e., log2(Keyspace).
+	Bits float64
+}
+
+// New returns the Stats for the given keyspace.
+func New(keyspace *big.Int) Stats {
+	return Stats{
+		Keyspace: keyspace,
+		Bits:     Bits(keyspace),
+	}
+}
+
+// String returns a human-readable version of the Stats.
+func (s Stats) String() string {
+	return fmt.Sprintf("%.2f bits (%s possibilities)", s.Bits, s.Keyspace.String())
+}
+
+// Bits returns log2(keyspace); it returns 0 for keyspace values less than 1.
+func Bits(keyspace *big.Int) float64 {
+	if keyspace == nil || keyspace.Sign() <= 0 {
+		return 0
+	}
+
+	// keyspace = mantissa * 2^exp, with mantissa in [0.5, 1)
+	mantissa := new(big.Float)
+	exp := new(big.Float).SetInt(keyspace).MantExp(mantissa)
+	m, _ := mantissa.Float64()
+	return float64(exp) + math.Log2(m)
+}
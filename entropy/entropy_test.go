@@ -0,0 +1,30 @@
+package entropy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBits(t *testing.T) {
+	assert.Equal(t, 0.0, Bits(nil))
+	assert.Equal(t, 0.0, Bits(big.NewInt(0)))
+	assert.Equal(t, 0.0, Bits(big.NewInt(-1)))
+	assert.Equal(t, 0.0, Bits(big.NewInt(1)))
+	assert.Equal(t, 1.0, Bits(big.NewInt(2)))
+	assert.Equal(t, 10.0, Bits(big.NewInt(1024)))
+	assert.InDelta(t, 3.321928, Bits(big.NewInt(10)), 0.000001)
+
+	huge := new(big.Int).Exp(big.NewInt(2), big.NewInt(4096), nil)
+	assert.Equal(t, 4096.0, Bits(huge))
+	huge.Mul(huge, big.NewInt(3))
+	assert.InDelta(t, 4097.584963, Bits(huge), 0.000001)
+}
+
+func TestNew(t *testing.T) {
+	s := New(big.NewInt(1024))
+	assert.Equal(t, "1024", s.Keyspace.String())
+	assert.Equal(t, 10.0, s.Bits)
+	assert.Equal(t, "10.00 bits (1024 possibilities)", s.String())
+}
@@ -1,11 +1,13 @@
 package enumerator
 
 import (
+	"crypto/rand"
 	"math/big"
 	"strings"
 	"sync"
 
 	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/rng"
 )
 
 var (
@@ -40,6 +42,15 @@ type Enumerator interface {
 	String() string
 }
 
+// RandomEnumerator is an Enumerator that can also move its gears to a random
+// location; the Enumerators returned by New implement it.
+type RandomEnumerator interface {
+	Enumerator
+	// GoToRandom moves the gears to a random location in the list of possible
+	// locations, using the Source set with WithRandomSource.
+	GoToRandom() error
+}
+
 type enumerator struct {
 	base        int
 	baseBigInt  *big.Int
@@ -48,6 +59,7 @@ type enumerator struct {
 	location    *big.Int
 	locationMax *big.Int
 	mutex       sync.RWMutex
+	rng         rng.Source
 	rollover    bool
 	value       []int
 	isASCII     bool
@@ -84,6 +96,7 @@ func New(cs charset.Charset, length int, opts ...Option) Enumerator {
 		length:        length,
 		location:      big.NewInt(1),
 		locationMax:   new(big.Int).Set(maxValues),
+		rng:           rng.Default(),
 		value:         make([]int, length),
 		dividend:      new(big.Int),
 		remainder:     new(big.Int),
@@ -309,14 +322,24 @@ func (o *enumerator) GoTo(n *big.Int) error {
 	if n.Cmp(biOne) < 0 || n.Cmp(o.locationMax) > 0 {
 		return ErrInvalidLocation
 	}
-	if o.useUint64 {
-		o.locationUint64 = n.Uint64()
-		o.location.SetUint64(o.locationUint64)
-	} else {
-		o.location.Set(n)
+	o.goTo(n)
+	return nil
+}
+
+func (o *enumerator) GoToRandom() error {
+	if o.locationMax.Sign() <= 0 {
+		return ErrInvalidLocation
 	}
-	o.computeValue()
-	o.locationDirty = false // location is now in sync with value
+	n, err := rand.Int(o.rng, o.locationMax)
+	if err != nil {
+		return err
+	}
+	n.Add(n, biOne)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.goTo(n)
 	return nil
 }
 
@@ -404,6 +427,17 @@ func (o *enumerator) first() {
 	o.stringDirty = true
 }
 
+func (o *enumerator) goTo(n *big.Int) {
+	if o.useUint64 {
+		o.locationUint64 = n.Uint64()
+		o.location.SetUint64(o.locationUint64)
+	} else {
+		o.location.Set(n)
+	}
+	o.computeValue()
+	o.locationDirty = false // location is now in sync with value
+}
+
 func (o *enumerator) incrementAtIndex(idx int) bool {
 	if o.value[idx] < o.base-1 {
 		o.value[idx]++
@@ -3,9 +3,12 @@ package enumerator
 import (
 	"errors"
 	"math/big"
+	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/rng"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -214,6 +217,36 @@ func TestEnumerator_GoTo(t *testing.T) {
 	assert.True(t, errors.Is(err, ErrInvalidLocation))
 }
 
+func TestEnumerator_GoToRandom(t *testing.T) {
+	o := New(charset.Numbers, 2).(RandomEnumerator)
+	for idx := 0; idx < 100; idx++ {
+		err := o.GoToRandom()
+		assert.Nil(t, err)
+		assert.True(t, o.Location().Cmp(big.NewInt(1)) >= 0, o.Location())
+		assert.True(t, o.Location().Cmp(big.NewInt(100)) <= 0, o.Location())
+		assert.Equal(t, o.Location().Int64()-1, mustParseInt(t, o.String()))
+	}
+
+	o1 := New(charset.AllChars, 16, WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0))))).(RandomEnumerator)
+	o2 := New(charset.AllChars, 16, WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0))))).(RandomEnumerator)
+	for idx := 0; idx < 10; idx++ {
+		assert.Nil(t, o1.GoToRandom())
+		assert.Nil(t, o2.GoToRandom())
+		assert.Equal(t, o1.String(), o2.String())
+	}
+
+	o = New(charset.Numbers, 0).(RandomEnumerator)
+	err := o.GoToRandom()
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidLocation))
+}
+
+func mustParseInt(t *testing.T, s string) int64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	assert.Nil(t, err)
+	return n
+}
+
 func TestEnumerator_Increment(t *testing.T) {
 	o := New(charset.Numbers, 3)
 	assert.Equal(t, "1", o.Location().String())
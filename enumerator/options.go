@@ -1,5 +1,7 @@
 package enumerator
 
+import "github.com/jedib0t/go-passwords/rng"
+
 type Option func(o *enumerator)
 
 var (
@@ -13,3 +15,16 @@ func WithRolloverEnabled(r bool) Option {
 		o.rollover = r
 	}
 }
+
+// WithRandomSource sets the source of randomness used by GoToRandom (see
+// RandomEnumerator). A nil Source resets it to the default buffered
+// crypto/rand Source.
+func WithRandomSource(src rng.Source) Option {
+	if src == nil {
+		src = rng.Default()
+	}
+
+	return func(o *enumerator) {
+		o.rng = src
+	}
+}
@@ -2,7 +2,11 @@ module github.com/jedib0t/go-passwords
 
 go 1.22
 
-require github.com/stretchr/testify v1.9.0
+require (
+	github.com/rivo/uniseg v0.4.7 // grapheme clusters for word lengths and capitalization
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/text v0.22.0 // NFC normalization of the words
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
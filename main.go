@@ -8,6 +8,9 @@ import (
 	"github.com/jedib0t/go-passwords/passphrase"
 	"github.com/jedib0t/go-passwords/passphrase/dictionaries"
 	"github.com/jedib0t/go-passwords/password"
+	"github.com/jedib0t/go-passwords/pin"
+	"github.com/jedib0t/go-passwords/pronounceable"
+	"github.com/jedib0t/go-passwords/strength"
 )
 
 var (
@@ -26,6 +29,9 @@ func main() {
 	report("Enumerator", demoEnumerator)
 	report("Passphrases", demoPassphraseGenerator)
 	report("Passwords", demoPasswordGenerator)
+	report("PINs", demoPINGenerator)
+	report("Pronounceable Passwords", demoPronounceableGenerator)
+	report("Strength", demoStrength)
 }
 
 func demoEnumerator() {
@@ -44,7 +50,7 @@ func demoEnumerator() {
 func demoPassphraseGenerator() {
 	g, err := passphrase.NewGenerator(
 		passphrase.WithCapitalizedWords(true),
-		passphrase.WithDictionary(dictionaries.English()),
+		passphrase.WithDictionary(dictionaries.EFFLong()),
 		passphrase.WithNumWords(3),
 		passphrase.WithNumber(true),
 		passphrase.WithSeparator("-"),
@@ -81,3 +87,45 @@ func demoPasswordGenerator() {
 		fmt.Printf("Password #%3d: %#v\n", idx, pw)
 	}
 }
+
+func demoPINGenerator() {
+	g, err := pin.NewGenerator(
+		pin.WithLength(6),
+	)
+	if err != nil {
+		panic(err.Error())
+	}
+	for idx := 1; idx <= 10; idx++ {
+		p, err := g.Generate()
+		if err != nil {
+			panic(err.Error())
+		}
+		fmt.Printf("PIN #%3d: %#v\n", idx, p)
+	}
+}
+
+func demoPronounceableGenerator() {
+	g, err := pronounceable.NewGenerator(
+		pronounceable.WithCapitalization(pronounceable.CapitalizeFirst),
+		pronounceable.WithLength(12),
+		pronounceable.WithNumDigits(2),
+	)
+	if err != nil {
+		panic(err.Error())
+	}
+	for idx := 1; idx <= 10; idx++ {
+		pw, err := g.Generate()
+		if err != nil {
+			panic(err.Error())
+		}
+		fmt.Printf("Password #%3d: %#v\n", idx, pw)
+	}
+}
+
+func demoStrength() {
+	for _, pw := range []string{"password", "P@ssw0rd", "qwerty123", "13-05-1991", "correcthorsebatterystaple"} {
+		result := strength.Estimate(pw)
+		fmt.Printf("%-27q: score=%d, entropy=%5.2f bits, warning=%q\n",
+			pw, result.Score, result.Entropy, result.Feedback.Warning)
+	}
+}
@@ -0,0 +1,152 @@
+package passphrase
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Casing controls the case of the words in the passphrases, and is applied
+// every time a passphrase is generated.
+type Casing int
+
+const (
+	// CasingNone leaves the words as they are in the dictionary (capitalized
+	// if asked for using WithCapitalizedWords).
+	CasingNone Casing = iota
+	// CasingLower converts every word to lower-case ("foo-bar-baz").
+	CasingLower
+	// CasingUpper converts every word to upper-case ("FOO-BAR-BAZ").
+	CasingUpper
+	// CasingTitle converts the first character of every word to title case,
+	// and the rest to lower-case ("Foo-Bar-Baz").
+	CasingTitle
+	// CasingAlternating converts every other word to upper-case, starting
+	// with the second one ("foo-BAR-baz").
+	CasingAlternating
+	// CasingRandomWordUpper converts one randomly chosen word to upper-case,
+	// and the rest to lower-case ("foo-bar-BAZ").
+	CasingRandomWordUpper
+	// CasingRandom converts every word to lower-case, title case or
+	// upper-case at random ("Foo-bar-BAZ").
+	CasingRandom
+)
+
+// isRandom returns true if the case of the words is chosen at random, and so
+// adds to the entropy of the passphrases.
+func (c Casing) isRandom() bool {
+	return c == CasingRandomWordUpper || c == CasingRandom
+}
+
+// wordCase is the case of a single word in a passphrase.
+type wordCase int
+
+const (
+	wordCaseLower wordCase = iota
+	wordCaseTitle
+	wordCaseUpper
+	numWordCases
+)
+
+// caseWords converts all the words in the dictionary to lower-case if they
+// are cased when generating the passphrases, or capitalizes them if asked for.
+func (g *generator) caseWords() {
+	if g.casing == CasingNone {
+		g.capitalizeWords()
+		return
+	}
+	for idx, word := range g.dictionary {
+		g.dictionary[idx] = norm.NFC.String(strings.ToLower(word))
+	}
+}
+
+// casedWordVariants returns the (lower-case) word in every case a passphrase
+// may have it in.
+func casedWordVariants(word string) [numWordCases]string {
+	return [numWordCases]string{
+		wordCaseLower: word,
+		wordCaseTitle: capitalizeWord(word),
+		wordCaseUpper: norm.NFC.String(strings.ToUpper(word)),
+	}
+}
+
+// sanitizeCasing prepares the words in every case ahead of time so that
+// GenerateTo does not have to.
+func (g *generator) sanitizeCasing() {
+	g.casedWords = [numWordCases][]string{}
+	if g.casing == CasingNone {
+		return
+	}
+
+	for wc := range g.casedWords {
+		g.casedWords[wc] = make([]string, len(g.dictionary))
+	}
+	for idx, word := range g.dictionary {
+		for wc, variant := range casedWordVariants(word) {
+			g.casedWords[wc][idx] = variant
+		}
+	}
+}
+
+// variants returns the cases in which the word at the given index has a
+// distinct variant when cased at random (ex.: only lower-case for "2024",
+// lower-case and title case for "a"/"A", and every case for
+// "foo"/"Foo"/"FOO").
+func (g *generator) variants(wordIndex int) [numWordCases]bool {
+	rsp := [numWordCases]bool{wordCaseLower: true}
+	if !g.casing.isRandom() {
+		return rsp
+	}
+
+	lower := g.casedWords[wordCaseLower][wordIndex]
+	title := g.casedWords[wordCaseTitle][wordIndex]
+	upper := g.casedWords[wordCaseUpper][wordIndex]
+	rsp[wordCaseTitle] = title != lower
+	rsp[wordCaseUpper] = upper != lower && upper != title
+	return rsp
+}
+
+// pickCases fills the given slice with the case of every word in the
+// passphrase.
+func (g *generator) pickCases(cases []wordCase) error {
+	for idx := range cases {
+		cases[idx] = wordCaseLower
+	}
+	switch g.casing {
+	case CasingTitle:
+		for idx := range cases {
+			cases[idx] = wordCaseTitle
+		}
+	case CasingUpper:
+		for idx := range cases {
+			cases[idx] = wordCaseUpper
+		}
+	case CasingAlternating:
+		for idx := 1; idx < len(cases); idx += 2 {
+			cases[idx] = wordCaseUpper
+		}
+	case CasingRandomWordUpper:
+		idx, err := g.rng.IntN(len(cases))
+		if err != nil {
+			return err
+		}
+		cases[idx] = wordCaseUpper
+	case CasingRandom:
+		for idx := range cases {
+			wc, err := g.rng.IntN(int(numWordCases))
+			if err != nil {
+				return err
+			}
+			cases[idx] = wordCase(wc)
+		}
+	}
+	return nil
+}
+
+// word returns the word at the given index in the given case.
+func (g *generator) word(wordIndex int, wc wordCase) string {
+	if g.casing == CasingNone {
+		return g.dictionary[wordIndex]
+	}
+	return g.casedWords[wc][wordIndex]
+}
@@ -0,0 +1,135 @@
+package passphrase
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Generate_WithCasing(t *testing.T) {
+	dict := make([]string, 0, 300)
+	for idx := 0; idx < 300; idx++ {
+		dict = append(dict, fmt.Sprintf("Word%03d", idx))
+	}
+
+	for casing, isValid := range map[Casing]func(words []string) bool{
+		CasingLower: func(words []string) bool {
+			return words[0] == strings.ToLower(words[0]) && words[3] == strings.ToLower(words[3])
+		},
+		CasingUpper: func(words []string) bool {
+			return words[0] == strings.ToUpper(words[0]) && words[3] == strings.ToUpper(words[3])
+		},
+		CasingTitle: func(words []string) bool {
+			return words[0] == capitalizeWord(strings.ToLower(words[0])) && words[3] == capitalizeWord(strings.ToLower(words[3]))
+		},
+		CasingAlternating: func(words []string) bool {
+			return words[0] == strings.ToLower(words[0]) && words[1] == strings.ToUpper(words[1]) &&
+				words[2] == strings.ToLower(words[2]) && words[3] == strings.ToUpper(words[3])
+		},
+		CasingRandomWordUpper: func(words []string) bool {
+			numUpper := 0
+			for _, word := range words {
+				if word == strings.ToUpper(word) {
+					numUpper++
+				} else if word != strings.ToLower(word) {
+					return false
+				}
+			}
+			return numUpper == 1
+		},
+	} {
+		t.Run(fmt.Sprint(casing), func(t *testing.T) {
+			g, err := NewGenerator(
+				WithCapitalizedWords(true),
+				WithCasing(casing),
+				WithDictionary(dict),
+				WithNumWords(4),
+				WithNumber(false),
+			)
+			assert.Nil(t, err)
+
+			for idx := 0; idx < 100; idx++ {
+				passphrase, err := g.Generate()
+				assert.NoError(t, err)
+				assert.True(t, isValid(strings.Split(passphrase, "-")), passphrase)
+			}
+		})
+	}
+
+	t.Run("random", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCasing(CasingRandom),
+			WithDictionary(dict),
+			WithNumWords(4),
+		)
+		assert.Nil(t, err)
+
+		cases := make(map[string]bool)
+		for idx := 0; idx < 1000; idx++ {
+			passphrase, err := g.Generate()
+			assert.NoError(t, err)
+			for _, word := range strings.Split(passphrase, "-") {
+				cases[strings.Trim(word, "0123456789")] = true
+			}
+		}
+		assert.Equal(t, map[string]bool{"word": true, "Word": true, "WORD": true}, cases)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		g, err := NewGenerator(WithCasing(CasingRandom + 1))
+		assert.Nil(t, g)
+		assert.Equal(t, ErrCasingInvalid, err)
+	})
+}
+
+func TestGenerator_Entropy_WithCasing(t *testing.T) {
+	// words that can be cased in 1 ("0000"), 2 ("x000"/"X000") and 3
+	// ("ab00"/"Ab00"/"AB00") distinct ways
+	var dict []string
+	for idx := 0; idx < 100; idx++ {
+		dict = append(dict, fmt.Sprintf("%04d", idx), fmt.Sprintf("x%03d", idx), fmt.Sprintf("ab%02d", idx))
+	}
+
+	for _, casing := range []Casing{CasingLower, CasingAlternating, CasingRandomWordUpper, CasingRandom} {
+		t.Run(fmt.Sprint(casing), func(t *testing.T) {
+			g, err := NewGenerator(
+				WithCasing(casing),
+				WithDictionary(dict),
+				WithNumWords(2),
+				WithNumber(false),
+			)
+			assert.Nil(t, err)
+			gen := g.(*generator)
+
+			// count every distinct passphrase that can be generated
+			var allCases [][]wordCase
+			switch casing {
+			case CasingRandomWordUpper:
+				allCases = [][]wordCase{{wordCaseUpper, wordCaseLower}, {wordCaseLower, wordCaseUpper}}
+			case CasingRandom:
+				for wc1 := wordCaseLower; wc1 < numWordCases; wc1++ {
+					for wc2 := wordCaseLower; wc2 < numWordCases; wc2++ {
+						allCases = append(allCases, []wordCase{wc1, wc2})
+					}
+				}
+			default:
+				cases := make([]wordCase, 2)
+				assert.NoError(t, gen.pickCases(cases))
+				allCases = [][]wordCase{cases}
+			}
+			passphrases := make(map[string]bool)
+			for idx1 := range gen.dictionary {
+				for idx2 := range gen.dictionary {
+					for _, cases := range allCases {
+						if idx1 != idx2 {
+							passphrases[gen.word(idx1, cases[0])+"-"+gen.word(idx2, cases[1])] = true
+						}
+					}
+				}
+			}
+			assert.Equal(t, fmt.Sprint(len(passphrases)), g.Entropy().Keyspace.String())
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package passphrase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-passwords/passphrase/dictionaries"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DicewareGenerator is a Generator that works on a Diceware word list, where
+// every word is identified by the rolls of a number of dice (ex.: "16655"
+// for the 5 dice used with the standard lists of 7776 words). This allows the
+// passphrases to be generated (or verified) offline with physical dice.
+type DicewareGenerator interface {
+	Generator
+	// GenerateWithRolls returns a randomly generated passphrase along with
+	// the dice rolls for each of its words.
+	GenerateWithRolls() (string, []string, error)
+	// Lookup returns the passphrase made up of the words for the given dice
+	// rolls, one per word (ex.: "16655").
+	Lookup(rolls []string) (string, error)
+}
+
+var (
+	dicewareRules = []Rule{
+		WithCapitalizedWords(false),
+		WithNumber(false),
+		WithSeparator(" "),
+		WithWordLength(0, 0),
+	}
+)
+
+// NewDicewareGenerator returns a passphrase generator that implements the
+// DicewareGenerator interface, and works on the EFF long word list unless a
+// dictionary is set using WithDictionary. The dictionary has to be a Diceware
+// word list with 6^n words (ex.: 216 words for 3 dice, 7776 words for 5 dice),
+// in the order of their dice rolls; the words may be prefixed by their dice
+// rolls as in the published lists (ex.: "11111\tabacus"). The order of the
+// words is preserved, and like with dice, the same word may be picked more
+// than once; so MinWordsInDictionary does not apply.
+//
+// By default the words are separated by a space and not capitalized. Words
+// cannot be filtered by length, excluded or cased at random, nor random
+// numbers, symbols or separators injected, as the passphrases would no longer
+// match the dice rolls.
+func NewDicewareGenerator(rules ...Rule) (DicewareGenerator, error) {
+	g := &generator{diceware: true}
+	rules = append([]Rule{WithDictionary(dictionaries.EFFLong())}, rules...)
+	for _, opt := range append(append(basicRules, dicewareRules...), rules...) {
+		opt(g)
+	}
+	if _, err := g.sanitize(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// GenerateWithRolls returns a randomly generated passphrase along with the
+// dice rolls for each of its words.
+func (g *generator) GenerateWithRolls() (string, []string, error) {
+	wordIndices := make([]int, g.numWords)
+	if err := g.pickWords(wordIndices); err != nil {
+		return "", nil, err
+	}
+
+	passphrase, err := g.wordsToString(wordIndices)
+	if err != nil {
+		return "", nil, err
+	}
+	rolls := make([]string, len(wordIndices))
+	for idx, wordIndex := range wordIndices {
+		rolls[idx] = g.diceRolls(wordIndex)
+	}
+	return passphrase, rolls, nil
+}
+
+// Lookup returns the passphrase made up of the words for the given dice
+// rolls, one per word (ex.: "16655").
+func (g *generator) Lookup(rolls []string) (string, error) {
+	if len(rolls) != g.numWords {
+		return "", fmt.Errorf("%w: found rolls for %d words, need %d", ErrDiceRollsInvalid, len(rolls), g.numWords)
+	}
+
+	wordIndices := make([]int, len(rolls))
+	for idx, roll := range rolls {
+		wordIndex, ok := g.wordIndex(roll)
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrDiceRollsInvalid, roll)
+		}
+		wordIndices[idx] = wordIndex
+	}
+	return g.wordsToString(wordIndices)
+}
+
+// wordsToString returns the passphrase made up of the words at the given
+// indices.
+func (g *generator) wordsToString(wordIndices []int) (string, error) {
+	wordCases := make([]wordCase, len(wordIndices))
+	if err := g.pickCases(wordCases); err != nil {
+		return "", err
+	}
+
+	var l layout
+	if err := g.pickLayout(&l); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, g.maxBytes())
+	n, err := g.writeWordsToBuf(buf, &l, wordIndices, wordCases)
+	if err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+// diceRolls returns the dice rolls for the word at the given index; the word
+// at index 0 is rolled as all ones.
+func (g *generator) diceRolls(wordIndex int) string {
+	rolls := make([]byte, g.numDice)
+	for idx := len(rolls) - 1; idx >= 0; idx-- {
+		rolls[idx] = byte('1' + wordIndex%6)
+		wordIndex /= 6
+	}
+	return string(rolls)
+}
+
+// wordIndex returns the index of the word for the given dice rolls.
+func (g *generator) wordIndex(rolls string) (int, bool) {
+	if len(rolls) != g.numDice {
+		return 0, false
+	}
+	wordIndex := 0
+	for idx := 0; idx < len(rolls); idx++ {
+		if rolls[idx] < '1' || rolls[idx] > '6' {
+			return 0, false
+		}
+		wordIndex = wordIndex*6 + int(rolls[idx]-'1')
+	}
+	return wordIndex, true
+}
+
+func (g *generator) sanitizeDicewareDictionary() error {
+	if g.hasNonDicewareRules() {
+		return ErrDicewareRuleUnsupported
+	}
+
+	// the number of words has to be a power of 6
+	for numWords := len(g.dictionary); numWords > 1 && numWords%6 == 0; numWords /= 6 {
+		g.numDice++
+	}
+	if g.numDice == 0 || len(g.dictionary) != g.diceLen() {
+		return fmt.Errorf("%w: found %d words", ErrDicewareDictionaryInvalid, len(g.dictionary))
+	}
+
+	// strip the dice rolls from the words without modifying the given list
+	words := make([]string, len(g.dictionary))
+	for idx, entry := range g.dictionary {
+		word, err := g.parseDicewareEntry(idx, entry)
+		if err != nil {
+			return err
+		}
+		words[idx] = word
+	}
+	g.dictionary = words
+	g.caseWords()
+
+	// every word has to be unique for the passphrases to be distinct
+	seen := make(map[string]bool, len(g.dictionary))
+	for _, word := range g.dictionary {
+		if seen[word] {
+			return fmt.Errorf("%w: found %q more than once", ErrDicewareDictionaryInvalid, word)
+		}
+		seen[word] = true
+	}
+	return nil
+}
+
+// hasNonDicewareRules returns true if any of the rules would make the
+// passphrases no longer match the dice rolls.
+func (g *generator) hasNonDicewareRules() bool {
+	return g.wordLenMin != 0 || g.wordLenMax != 0 || len(g.excludedSubstrings) > 0 || g.casing.isRandom() ||
+		g.numbers.count > 0 || g.symbols.count > 0 || g.randomSeparators || g.isLengthLimited() || g.hasPolicy()
+}
+
+// diceLen returns the number of words that can be rolled with the dice.
+func (g *generator) diceLen() int {
+	rsp := 1
+	for idx := 0; idx < g.numDice; idx++ {
+		rsp *= 6
+	}
+	return rsp
+}
+
+// parseDicewareEntry returns the word in the given entry of the dictionary,
+// after ensuring the dice rolls it may be prefixed with match its index.
+func (g *generator) parseDicewareEntry(wordIndex int, entry string) (string, error) {
+	word := strings.TrimSpace(entry)
+	if fields := strings.Fields(word); len(fields) == 2 {
+		if idx, ok := g.wordIndex(fields[0]); ok {
+			if idx != wordIndex {
+				return "", fmt.Errorf("%w: found %q at %q", ErrDicewareDictionaryInvalid, entry, g.diceRolls(wordIndex))
+			}
+			word = fields[1]
+		}
+	}
+	if word == "" || !utf8.ValidString(word) {
+		return "", fmt.Errorf("%w: found %q at %q", ErrDicewareDictionaryInvalid, entry, g.diceRolls(wordIndex))
+	}
+	return norm.NFC.String(word), nil
+}
@@ -0,0 +1,193 @@
+package passphrase
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/passphrase/dictionaries"
+	"github.com/jedib0t/go-passwords/rng"
+	"github.com/stretchr/testify/assert"
+)
+
+// dicewareList returns a Diceware word list for the given number of dice, with
+// the words optionally prefixed by their dice rolls.
+func dicewareList(numDice int, withRolls bool) []string {
+	g := &generator{numDice: numDice}
+	words := make([]string, g.diceLen())
+	for idx := range words {
+		words[idx] = fmt.Sprintf("w%s", g.diceRolls(idx))
+		if withRolls {
+			words[idx] = g.diceRolls(idx) + "\t" + words[idx]
+		}
+	}
+	return words
+}
+
+func TestNewDicewareGenerator(t *testing.T) {
+	for _, numDice := range []int{3, 4, 5} {
+		for _, withRolls := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%d dice/with rolls=%v", numDice, withRolls), func(t *testing.T) {
+				dict := dicewareList(numDice, withRolls)
+				g, err := NewDicewareGenerator(WithDictionary(dict), WithNumWords(4))
+				assert.Nil(t, err)
+				assert.Equal(t, dicewareList(numDice, withRolls), dict, "dictionary must not be modified")
+
+				for idx := 0; idx < 100; idx++ {
+					passphrase, rolls, err := g.GenerateWithRolls()
+					assert.NoError(t, err)
+					assert.Len(t, rolls, 4)
+					// the words are named after their rolls
+					words := strings.Split(passphrase, " ")
+					for wIdx, word := range words {
+						assert.Equal(t, "w"+rolls[wIdx], word)
+					}
+
+					lookedUp, err := g.Lookup(rolls)
+					assert.NoError(t, err)
+					assert.Equal(t, passphrase, lookedUp)
+				}
+
+				passphrase, err := g.Generate()
+				assert.NoError(t, err)
+				assert.Len(t, strings.Split(passphrase, " "), 4)
+			})
+		}
+	}
+}
+
+func TestNewDicewareGenerator_PreservesOrder(t *testing.T) {
+	// reverse the order of the words so that sorting would break the lookups
+	dict := dicewareList(5, false)
+	for left, right := 0, len(dict)-1; left < right; left, right = left+1, right-1 {
+		dict[left], dict[right] = dict[right], dict[left]
+	}
+	g, err := NewDicewareGenerator(
+		WithCapitalizedWords(true),
+		WithDictionary(dict),
+		WithSeparator("-"),
+	)
+	assert.Nil(t, err)
+
+	passphrase, err := g.Lookup([]string{"11111", "66666", "16655"})
+	assert.NoError(t, err)
+	assert.Equal(t, "W66666-W11111-W61122", passphrase)
+}
+
+func TestNewDicewareGenerator_EFF(t *testing.T) {
+	g, err := NewDicewareGenerator()
+	assert.Nil(t, err)
+	passphrase, err := g.Lookup([]string{"11111", "66666", "16655"})
+	assert.NoError(t, err)
+	assert.Equal(t, "abacus zoom contusion", passphrase)
+	assert.InDelta(t, 38.77, g.Entropy().Bits, 0.01)
+
+	g, err = NewDicewareGenerator(
+		WithCapitalizedWords(true),
+		WithDictionary(dictionaries.EFFShortUniquePrefix()),
+		WithNumWords(4),
+		WithSeparator("-"),
+	)
+	assert.Nil(t, err)
+	passphrase, err = g.Lookup([]string{"1111", "6666", "1665", "3452"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Aardvark-Zucchini-Coffeecake-Hybrid", passphrase)
+
+	g, err = NewDicewareGenerator(WithCasing(CasingAlternating))
+	assert.Nil(t, err)
+	passphrase, err = g.Lookup([]string{"11111", "66666", "16655"})
+	assert.NoError(t, err)
+	assert.Equal(t, "abacus ZOOM contusion", passphrase)
+
+	g, err = NewDicewareGenerator(WithCasing(CasingRandom))
+	assert.Nil(t, g)
+	assert.Equal(t, ErrDicewareRuleUnsupported, err)
+}
+
+func TestDicewareGenerator_Lookup_Errors(t *testing.T) {
+	g, err := NewDicewareGenerator(WithDictionary(dicewareList(5, false)), WithNumWords(2))
+	assert.Nil(t, err)
+
+	for _, rolls := range [][]string{
+		{"11111"},
+		{"11111", "11111", "11111"},
+		{"11111", "1111"},
+		{"11111", "111111"},
+		{"11111", "11171"},
+		{"11111", "1a111"},
+	} {
+		passphrase, err := g.Lookup(rolls)
+		assert.Empty(t, passphrase)
+		assert.True(t, errors.Is(err, ErrDiceRollsInvalid), rolls)
+	}
+	_, err = g.Lookup([]string{"11111", "11171"})
+	assert.EqualError(t, err, `dice rolls invalid: "11171"`)
+}
+
+func TestDicewareGenerator_Entropy(t *testing.T) {
+	g, err := NewDicewareGenerator(WithDictionary(dicewareList(5, false)), WithNumWords(6))
+	assert.Nil(t, err)
+
+	expected := new(big.Int).Exp(big.NewInt(7776), big.NewInt(6), nil)
+	assert.Equal(t, expected, g.Entropy().Keyspace)
+	assert.InDelta(t, 77.55, g.Entropy().Bits, 0.01)
+
+	// the same word can be picked more than once, just like with dice
+	g, err = NewDicewareGenerator(
+		WithDictionary(dicewareList(4, false)),
+		WithNumWords(2),
+		WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+	)
+	assert.Nil(t, err)
+	repeated := false
+	for idx := 0; idx < 10000 && !repeated; idx++ {
+		_, rolls, err := g.GenerateWithRolls()
+		assert.NoError(t, err)
+		repeated = rolls[0] == rolls[1]
+	}
+	assert.True(t, repeated)
+}
+
+func TestNewDicewareGenerator_ErrorCases(t *testing.T) {
+	shuffled := dicewareList(4, true)
+	shuffled[0], shuffled[1] = shuffled[1], shuffled[0]
+	duplicated := dicewareList(4, false)
+	duplicated[1] = duplicated[0]
+	empty := dicewareList(4, false)
+	empty[42] = " "
+
+	for name, tc := range map[string]struct {
+		rules []Rule
+		err   error
+		msg   string
+	}{
+		"english":          {[]Rule{WithDictionary(dictionaries.English())}, ErrDicewareDictionaryInvalid, ""},
+		"not a power of 6": {[]Rule{WithDictionary(dicewareList(4, false)[:1000])}, ErrDicewareDictionaryInvalid, ""},
+		"rolls out of order": {[]Rule{WithDictionary(shuffled)}, ErrDicewareDictionaryInvalid,
+			`dictionary is not a Diceware word list with 6^n words in the order of their dice rolls: found "1112\tw1112" at "1111"`},
+		"duplicate words": {[]Rule{WithDictionary(duplicated)}, ErrDicewareDictionaryInvalid,
+			`dictionary is not a Diceware word list with 6^n words in the order of their dice rolls: found "w1111" more than once`},
+		"empty word":        {[]Rule{WithDictionary(empty)}, ErrDicewareDictionaryInvalid, ""},
+		"word length":       {[]Rule{WithDictionary(dicewareList(4, false)), WithWordLength(4, 7)}, ErrDicewareRuleUnsupported, ""},
+		"number":            {[]Rule{WithDictionary(dicewareList(4, false)), WithNumber(true)}, ErrDicewareRuleUnsupported, ""},
+		"excluded":          {[]Rule{WithDictionary(dicewareList(4, false)), WithExcludedSubstrings([]string{"w1"})}, ErrDicewareRuleUnsupported, ""},
+		"symbols":           {[]Rule{WithSymbols(charset.Symbols, 1)}, ErrDicewareRuleUnsupported, ""},
+		"random separators": {[]Rule{WithRandomSeparators(charset.Symbols)}, ErrDicewareRuleUnsupported, ""},
+		"max length":        {[]Rule{WithMaxLength(40)}, ErrDicewareRuleUnsupported, ""},
+		"too many words":    {[]Rule{WithDictionary(dicewareList(4, false)), WithNumWords(33)}, ErrNumWordsTooLarge, ""},
+		"entropy too low":   {[]Rule{WithDictionary(dicewareList(4, false)), WithMinEntropy(64)}, ErrEntropyTooLow, ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewDicewareGenerator(tc.rules...)
+			assert.Nil(t, g)
+			assert.True(t, errors.Is(err, tc.err), err)
+			if tc.msg != "" {
+				assert.EqualError(t, err, tc.msg)
+			}
+		})
+	}
+}
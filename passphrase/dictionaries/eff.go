@@ -0,0 +1,62 @@
+package dictionaries
+
+import (
+	_ "embed" // for embedding dictionary files
+	"slices"
+	"strings"
+	"sync"
+)
+
+// The EFF word lists are published by the Electronic Frontier Foundation
+// under the Creative Commons Attribution 3.0 United States license; see
+// https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases for
+// more details. Every line holds the dice rolls for a word, followed by a tab
+// and the word. Only the long list and the second short list are bundled; the
+// first short list (of the shortest words) can be used with
+// passphrase.WithDictionary.
+
+//go:embed eff_large_wordlist.txt
+var effLongTxtRaw string
+
+//go:embed eff_short_wordlist_2_0.txt
+var effShortUniquePrefixTxtRaw string
+
+var (
+	effLongWords              []string
+	effLongOnce               sync.Once
+	effShortUniquePrefixWords []string
+	effShortUniquePrefixOnce  sync.Once
+)
+
+// EFFLong returns the 7776 words in the EFF long (large) word list, meant for
+// 5 dice; the words are easy to remember and type, and are in the order of
+// their dice rolls so that they can be used with a passphrase.DicewareGenerator.
+func EFFLong() []string {
+	effLongOnce.Do(func() {
+		effLongWords = parseDicewareList(effLongTxtRaw)
+	})
+	return slices.Clone(effLongWords)
+}
+
+// EFFShortUniquePrefix returns the 1296 words in the second EFF short word
+// list, meant for 4 dice; every word can be auto-completed from its first 3
+// characters, and no word is within an edit distance of 1 from another. The
+// words are in the order of their dice rolls so that they can be used with a
+// passphrase.DicewareGenerator.
+func EFFShortUniquePrefix() []string {
+	effShortUniquePrefixOnce.Do(func() {
+		effShortUniquePrefixWords = parseDicewareList(effShortUniquePrefixTxtRaw)
+	})
+	return slices.Clone(effShortUniquePrefixWords)
+}
+
+// parseDicewareList returns the words in the given Diceware word list, in the
+// order of their dice rolls.
+func parseDicewareList(raw string) []string {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(raw, "\r", "")), "\n")
+	words := make([]string, len(lines))
+	for idx, line := range lines {
+		_, words[idx], _ = strings.Cut(line, "\t")
+	}
+	return words
+}
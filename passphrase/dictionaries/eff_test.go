@@ -0,0 +1,37 @@
+package dictionaries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEFFLong(t *testing.T) {
+	words := EFFLong()
+	assert.Len(t, words, 7776)
+	assert.Equal(t, "abacus", words[0])
+	assert.Equal(t, "zoom", words[7775])
+
+	// the words are in the order of their dice rolls, which is alphabetical
+	for idx := 1; idx < len(words); idx++ {
+		assert.Less(t, words[idx-1], words[idx])
+	}
+
+	// every call returns a copy
+	words[0] = "foo"
+	assert.Equal(t, "abacus", EFFLong()[0])
+}
+
+func TestEFFShortUniquePrefix(t *testing.T) {
+	words := EFFShortUniquePrefix()
+	assert.Len(t, words, 1296)
+	assert.Equal(t, "aardvark", words[0])
+	assert.Equal(t, "zucchini", words[1295])
+
+	prefixes := make(map[string]bool)
+	for _, word := range words {
+		assert.GreaterOrEqual(t, len(word), 3, word)
+		assert.False(t, prefixes[word[:3]], word)
+		prefixes[word[:3]] = true
+	}
+}
@@ -0,0 +1,7 @@
+package dictionaries
+
+import "errors"
+
+var (
+	ErrUnsupportedLanguage = errors.New("no dictionary found for the language")
+)
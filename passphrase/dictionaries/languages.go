@@ -0,0 +1,109 @@
+package dictionaries
+
+import (
+	_ "embed" // for embedding dictionary files
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// The French, Japanese, Portuguese and Spanish word lists are the 2048-word
+// lists from the BIP-0039 specification (https://github.com/bitcoin/bips),
+// normalized to the NFC form.
+
+//go:embed french.txt
+var frenchTxtRaw string
+
+//go:embed japanese.txt
+var japaneseTxtRaw string
+
+//go:embed portuguese.txt
+var portugueseTxtRaw string
+
+//go:embed spanish.txt
+var spanishTxtRaw string
+
+var (
+	frenchWords     []string
+	frenchOnce      sync.Once
+	japaneseWords   []string
+	japaneseOnce    sync.Once
+	portugueseWords []string
+	portugueseOnce  sync.Once
+	spanishWords    []string
+	spanishOnce     sync.Once
+
+	// languages maps the supported languages (as ISO 639-1 codes) to their
+	// dictionaries.
+	languages = map[string]func() []string{
+		"en": English,
+		"es": Spanish,
+		"fr": French,
+		"ja": Japanese,
+		"pt": Portuguese,
+	}
+)
+
+// French returns the 2048 French words from the BIP-0039 specification.
+func French() []string {
+	frenchOnce.Do(func() {
+		frenchWords = parseWordList(frenchTxtRaw)
+	})
+	return slices.Clone(frenchWords)
+}
+
+// Japanese returns the 2048 Japanese (Hiragana) words from the BIP-0039
+// specification.
+func Japanese() []string {
+	japaneseOnce.Do(func() {
+		japaneseWords = parseWordList(japaneseTxtRaw)
+	})
+	return slices.Clone(japaneseWords)
+}
+
+// Portuguese returns the 2048 Portuguese words from the BIP-0039
+// specification.
+func Portuguese() []string {
+	portugueseOnce.Do(func() {
+		portugueseWords = parseWordList(portugueseTxtRaw)
+	})
+	return slices.Clone(portugueseWords)
+}
+
+// Spanish returns the 2048 Spanish words from the BIP-0039 specification.
+func Spanish() []string {
+	spanishOnce.Do(func() {
+		spanishWords = parseWordList(spanishTxtRaw)
+	})
+	return slices.Clone(spanishWords)
+}
+
+// ByLanguage returns the dictionary for the language identified by the given
+// BCP 47 tag (ex.: "fr", "es-MX"); only the primary language subtag is used.
+// Only English, French, Japanese, Portuguese and Spanish are supported, and it
+// returns ErrUnsupportedLanguage for any other language (like German).
+func ByLanguage(tag string) ([]string, error) {
+	language, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	dictionary, ok := languages[strings.ToLower(language)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedLanguage, tag)
+	}
+	return dictionary(), nil
+}
+
+// Languages returns the (ISO 639-1 codes of the) languages supported by
+// ByLanguage.
+func Languages() []string {
+	rsp := make([]string, 0, len(languages))
+	for language := range languages {
+		rsp = append(rsp, language)
+	}
+	slices.Sort(rsp)
+	return rsp
+}
+
+// parseWordList returns the words in the given word list, one per line.
+func parseWordList(raw string) []string {
+	return strings.Split(strings.TrimSpace(strings.ReplaceAll(raw, "\r", "")), "\n")
+}
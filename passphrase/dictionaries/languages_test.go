@@ -0,0 +1,60 @@
+package dictionaries
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByLanguage(t *testing.T) {
+	for tag, firstWord := range map[string]string{
+		"es":    "ábaco",
+		"es-MX": "ábaco",
+		"fr":    "abaisser",
+		"FR_ca": "abaisser",
+		"ja":    "あいこくしん",
+		"pt":    "abacate",
+		"pt-BR": "abacate",
+	} {
+		t.Run(tag, func(t *testing.T) {
+			words, err := ByLanguage(tag)
+			assert.NoError(t, err)
+			assert.Len(t, words, 2048)
+			assert.Equal(t, firstWord, words[0])
+			for _, word := range words {
+				assert.True(t, utf8.ValidString(word), word)
+			}
+		})
+	}
+
+	words, err := ByLanguage("en-US")
+	assert.NoError(t, err)
+	assert.Equal(t, English(), words)
+
+	for _, tag := range []string{"", "xx", "eng", "de", "de-AT"} {
+		words, err := ByLanguage(tag)
+		assert.Nil(t, words)
+		assert.True(t, errors.Is(err, ErrUnsupportedLanguage))
+	}
+	_, err = ByLanguage("xx-YY")
+	assert.EqualError(t, err, `no dictionary found for the language: "xx-YY"`)
+}
+
+func TestLanguages(t *testing.T) {
+	assert.Equal(t, []string{"en", "es", "fr", "ja", "pt"}, Languages())
+	for _, language := range Languages() {
+		words, err := ByLanguage(language)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, words)
+	}
+}
+
+func TestFrench(t *testing.T) {
+	// accented characters are pre-composed (NFC) and not followed by a
+	// combining accent
+	words := French()
+	assert.Contains(t, words, "élève")
+	assert.Equal(t, "élève", string([]rune{'é', 'l', 'è', 'v', 'e'}))
+}
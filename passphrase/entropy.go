@@ -0,0 +1,244 @@
+package passphrase
+
+import (
+	"math/big"
+
+	"github.com/jedib0t/go-passwords/entropy"
+)
+
+// Entropy returns the exact number of distinct passphrases the Generator can
+// generate, and the bits of entropy it translates to.
+//
+// Note: passphrases are assumed to be distinct when their words, numbers and
+// symbols are; this does not hold true when the separator is empty or appears
+// within the words, or when the symbols or separators can be mistaken for the
+// rest. Words with excluded substrings are not counted, but the (rare)
+// passphrases with an excluded substring spanning multiple words are.
+func (g *generator) Entropy() entropy.Stats {
+	return entropy.New(g.keyspace(true))
+}
+
+// keyspace returns the number of distinct passphrases within the length
+// limits, counting only the ones that meet the policy if asked for.
+func (g *generator) keyspace(meetPolicy bool) *big.Int {
+	// the words, within the length limits
+	wordsLenMin, wordsLenMax := g.wordsLenLimits()
+	keyspaces := g.wordsKeyspaces(wordsLenMin, wordsLenMax)
+
+	// the positions of the numbers and symbols injected
+	numTokens := g.numWords
+	positions := g.numbers.positions(g.numWords, &numTokens)
+	positions.Mul(positions, g.symbols.positions(g.numWords, &numTokens))
+
+	// the values of the numbers and symbols, and the random separators
+	// between the words, numbers and symbols
+	keyspaces = g.combineKeyspaces(keyspaces, g.runesKeyspaces(g.numbers.runes, g.numbers.count*g.numbers.width, false))
+	keyspaces = g.combineKeyspaces(keyspaces, g.runesKeyspaces(g.symbols.runes, g.symbols.count*g.symbols.width, false))
+	keyspaces = g.combineKeyspaces(keyspaces, g.separatorsKeyspaces(numTokens))
+
+	keyspace := new(big.Int)
+	for counts, ways := range keyspaces {
+		if !meetPolicy || g.meetsPolicyCounts(counts) {
+			keyspace.Add(keyspace, ways)
+		}
+	}
+	return keyspace.Mul(keyspace, positions)
+}
+
+// numWordSequences returns the number of distinct sequences of k words picked
+// from n words.
+func (g *generator) numWordSequences(n int, k int64) *big.Int {
+	// diceware words are picked independently: n ^ k
+	if g.diceware {
+		return new(big.Int).Exp(big.NewInt(int64(n)), big.NewInt(k), nil)
+	}
+
+	// words are never repeated, and so the order matters: n! / (n - k)!
+	rsp := big.NewInt(1)
+	for idx := int64(0); idx < k; idx++ {
+		rsp.Mul(rsp, big.NewInt(int64(n)-idx))
+	}
+	return rsp
+}
+
+// wordClass groups the words that count the same towards the keyspace: of
+// the same length when the length of the passphrases is limited, with
+// distinct variants in the same cases when cased at random, and with as many
+// characters of every class (capped) in every case when the passphrases have
+// to meet a policy.
+type wordClass struct {
+	classes  [numWordCases]charClassCounts
+	length   int
+	variants [numWordCases]bool
+}
+
+// isCased returns true if the words in the class look different in
+// upper-case.
+func (wc wordClass) isCased() bool {
+	return wc.variants[wordCaseTitle] || wc.variants[wordCaseUpper]
+}
+
+// wordsState is a partial sequence of words while counting the keyspace.
+type wordsState struct {
+	classes    charClassCounts
+	hasUncased bool
+	length     int
+	numUpper   int
+	numWords   int
+}
+
+// sanitizeWordClasses counts the words in every class.
+func (g *generator) sanitizeWordClasses() {
+	g.wordClasses = make(map[wordClass]int)
+	for idx := range g.dictionary {
+		class := wordClass{variants: g.variants(idx)}
+		if g.wordLengths != nil {
+			class.length = g.wordLengths[idx]
+		}
+		if g.hasPolicy() {
+			for wc := range class.classes {
+				class.classes[wc] = g.capped(countCharClasses([]byte(g.word(idx, wordCase(wc)))))
+			}
+		}
+		g.wordClasses[class]++
+	}
+}
+
+// casingGroups returns the case of most of the words, and the number of words
+// upper-cased on their own (every other word when alternating, or the one
+// word picked at random).
+func (g *generator) casingGroups() (wordCase, int) {
+	switch g.casing {
+	case CasingUpper:
+		return wordCaseUpper, 0
+	case CasingTitle:
+		return wordCaseTitle, 0
+	case CasingAlternating:
+		return wordCaseLower, g.numWords / 2
+	case CasingRandomWordUpper:
+		return wordCaseLower, 1
+	}
+	return wordCaseLower, 0
+}
+
+// wordsKeyspace returns the number of distinct sequences of words, cased as
+// asked for, with a total length (in characters) within the given limits
+// (none if 0).
+func (g *generator) wordsKeyspace(lenMin, lenMax int) *big.Int {
+	rsp := new(big.Int)
+	for _, ways := range g.wordsKeyspaces(lenMin, lenMax) {
+		rsp.Add(rsp, ways)
+	}
+	return rsp
+}
+
+// wordsKeyspaces returns the number of distinct sequences of words, cased as
+// asked for, with a total length (in characters) within the given limits
+// (none if 0), by the (capped) number of characters of every class in them.
+// The sequences are built one class of words at a time, by picking the number
+// of words from the class (and how many of them are upper-cased on their own),
+// their positions among the words picked so far, and the words themselves.
+func (g *generator) wordsKeyspaces(lenMin, lenMax int) classKeyspaces {
+	states := map[wordsState]*big.Int{{}: big.NewInt(1)}
+	for class, numWords := range g.wordClasses {
+		variants := g.variantsKeyspaces(class)
+		next := make(map[wordsState]*big.Int, len(states))
+		for state, ways := range states {
+			g.pickFromWordClass(next, state, ways, class, numWords, variants, lenMax)
+		}
+		states = next
+	}
+
+	rsp := make(classKeyspaces)
+	for state, ways := range states {
+		if state.numWords == g.numWords && state.length >= lenMin {
+			addKeyspace(rsp, state.classes, ways.Mul(ways, g.numCasingOutcomes(state)))
+		}
+	}
+	return rsp
+}
+
+// pickFromWordClass adds the states reached by picking words from the class
+// after the given state to next; variants holds the ways to case any number of
+// the words picked.
+func (g *generator) pickFromWordClass(next map[wordsState]*big.Int, state wordsState, ways *big.Int, class wordClass, numWords int, variants []classKeyspaces, lenMax int) {
+	_, maxUpper := g.casingGroups()
+	upperClasses := class.classes[wordCaseUpper]
+	for count := 0; state.numWords+count <= g.numWords; count++ {
+		length := state.length + count*class.length
+		if lenMax > 0 && length > lenMax {
+			break
+		}
+
+		// the positions and the words picked, of which numUpper go in
+		// upper-case on their own (the one picked at random only if that
+		// makes a difference)
+		for numUpper := 0; numUpper <= min(count, maxUpper-state.numUpper); numUpper++ {
+			if numUpper > 0 && g.casing == CasingRandomWordUpper && !class.isCased() {
+				break
+			}
+			numOthers := count - numUpper
+			nextWays := new(big.Int).Binomial(int64(state.numWords-state.numUpper+numOthers), int64(numOthers))
+			nextWays.Mul(nextWays, new(big.Int).Binomial(int64(state.numUpper+numUpper), int64(numUpper)))
+			nextWays.Mul(nextWays, g.numWordSequences(numWords, int64(count)))
+			nextWays.Mul(nextWays, ways)
+
+			nextState := wordsState{
+				hasUncased: state.hasUncased || (g.casing == CasingRandomWordUpper && numOthers > 0 && !class.isCased()),
+				length:     length,
+				numUpper:   state.numUpper + numUpper,
+				numWords:   state.numWords + count,
+			}
+			for counts, variantWays := range variants[numOthers] {
+				nextState.classes = g.addCapped(state.classes, g.addCapped(counts, scaleCounts(upperClasses, numUpper)))
+				if next[nextState] == nil {
+					next[nextState] = new(big.Int)
+				}
+				next[nextState].Add(next[nextState], new(big.Int).Mul(nextWays, variantWays))
+			}
+		}
+	}
+}
+
+// variantsKeyspaces returns the number of distinct ways to case any number of
+// words from the class (in the case of most of the words, or any of their
+// variants when cased at random), by the (capped) number of characters of
+// every class in them.
+func (g *generator) variantsKeyspaces(class wordClass) []classKeyspaces {
+	mainCase, _ := g.casingGroups()
+	rsp := make([]classKeyspaces, g.numWords+1)
+	rsp[0] = classKeyspaces{{}: big.NewInt(1)}
+	for count := 1; count <= g.numWords; count++ {
+		rsp[count] = make(classKeyspaces)
+		for counts, ways := range rsp[count-1] {
+			for wc, ok := range class.variants {
+				if g.casing == CasingRandom && ok || g.casing != CasingRandom && wordCase(wc) == mainCase {
+					addKeyspace(rsp[count], g.addCapped(counts, class.classes[wc]), ways)
+				}
+			}
+		}
+	}
+	return rsp
+}
+
+// numCasingOutcomes returns the number of distinct ways a sequence of words can
+// be cased, beyond the ways to case every word at random which are accounted
+// for by the classes of the words.
+func (g *generator) numCasingOutcomes(state wordsState) *big.Int {
+	_, maxUpper := g.casingGroups()
+	switch {
+	case g.casing == CasingRandomWordUpper && state.numUpper == 0:
+		// one of the words that looks the same in upper-case, which leaves
+		// everything in lower-case
+		if state.hasUncased {
+			return big.NewInt(1)
+		}
+		return big.NewInt(0)
+	case g.casing == CasingRandomWordUpper:
+		// any of the words can be the one in upper-case
+		return big.NewInt(int64(state.numWords))
+	case state.numUpper != maxUpper:
+		return big.NewInt(0)
+	}
+	return big.NewInt(1)
+}
@@ -0,0 +1,292 @@
+package passphrase
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-passwords/passphrase/dictionaries"
+	"github.com/jedib0t/go-passwords/rng"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Entropy(t *testing.T) {
+	dict := make([]string, 0, 300)
+	for idx := 0; idx < 300; idx++ {
+		dict = append(dict, fmt.Sprintf("word%03d", idx))
+	}
+
+	t.Run("without number", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithDictionary(dict),
+			WithNumWords(3),
+			WithNumber(false),
+		)
+		assert.Nil(t, err)
+
+		stats := g.Entropy()
+		assert.Equal(t, fmt.Sprint(300*299*298), stats.Keyspace.String())
+		assert.InDelta(t, math.Log2(300*299*298), stats.Bits, 0.000001)
+	})
+
+	t.Run("with excluded substrings", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithDictionary(slices.Clone(dict)),
+			WithExcludedSubstrings([]string{"WORD00"}),
+			WithExcludedSubstringsIgnoreCase(true),
+			WithNumWords(3),
+			WithNumber(false),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint(290*289*288), g.Entropy().Keyspace.String())
+	})
+
+	t.Run("with number", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithDictionary(dict),
+			WithNumWords(2),
+			WithNumber(true),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint(300*299*2*10), g.Entropy().Keyspace.String())
+	})
+
+	t.Run("dictionary filtered by word length", func(t *testing.T) {
+		dict := append([]string{"a", "ab", "abcdefghijkl"}, dict...)
+		g, err := NewGenerator(
+			WithDictionary(dict),
+			WithNumWords(2),
+			WithNumber(false),
+			WithWordLength(4, 7),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint(300*299), g.Entropy().Keyspace.String())
+	})
+
+	t.Run("duplicates after capitalization", func(t *testing.T) {
+		dict := append([]string{"Word000", "WORD001"}, dict...)
+		g, err := NewGenerator(
+			WithCapitalizedWords(true),
+			WithDictionary(dict),
+			WithNumWords(2),
+			WithNumber(false),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint(301*300), g.Entropy().Keyspace.String())
+	})
+
+	t.Run("with policy", func(t *testing.T) {
+		dict := testDictionary(256, true)
+		for name, tc := range map[string]struct {
+			rules      []Rule
+			cases      [][]wordCase
+			separators []string
+			suffixes   []string
+		}{
+			"random casing": {
+				rules: []Rule{WithCasing(CasingRandom), WithMinLowerCase(1), WithMinUpperCase(5)},
+				cases: [][]wordCase{
+					{wordCaseLower, wordCaseLower}, {wordCaseLower, wordCaseTitle}, {wordCaseLower, wordCaseUpper},
+					{wordCaseTitle, wordCaseLower}, {wordCaseTitle, wordCaseTitle}, {wordCaseTitle, wordCaseUpper},
+					{wordCaseUpper, wordCaseLower}, {wordCaseUpper, wordCaseTitle}, {wordCaseUpper, wordCaseUpper},
+				},
+			},
+			"one random word upper-case": {
+				rules: []Rule{WithCasing(CasingRandomWordUpper), WithMinLowerCase(4), WithMinUpperCase(5)},
+				cases: [][]wordCase{{wordCaseUpper, wordCaseLower}, {wordCaseLower, wordCaseUpper}},
+			},
+			"alternating": {
+				rules: []Rule{WithCasing(CasingAlternating), WithMinDigits(4), WithMinUpperCase(1)},
+				cases: [][]wordCase{{wordCaseLower, wordCaseUpper}},
+			},
+			"random separators": {
+				rules:      []Rule{WithMinDigits(1), WithRandomSeparators("-1")},
+				separators: []string{"-", "1"},
+			},
+			"symbols": {
+				rules:    []Rule{WithMinSymbols(2), WithSymbols("!a", 1), WithSymbolPlacement(PlacementEnd)},
+				suffixes: []string{"!", "a"},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				g, err := NewGenerator(append([]Rule{
+					WithCapitalizedWords(false),
+					WithDictionary(dict),
+					WithNumWords(2),
+					WithNumber(false),
+					WithWordLength(4, 8),
+				}, tc.rules...)...)
+				assert.Nil(t, err)
+				gen := g.(*generator)
+
+				// count every distinct passphrase that meets the policy
+				cases := append(tc.cases, []wordCase{wordCaseLower, wordCaseLower})[:max(len(tc.cases), 1)]
+				separators := append(tc.separators, "-")[:max(len(tc.separators), 1)]
+				suffixes := append(tc.suffixes, "")[:max(len(tc.suffixes), 1)]
+				passphrases := make(map[string]bool)
+				for idx1 := range gen.dictionary {
+					for idx2 := range gen.dictionary {
+						for _, wc := range cases {
+							for _, separator := range separators {
+								for _, suffix := range suffixes {
+									passphrase := gen.word(idx1, wc[0]) + separator + gen.word(idx2, wc[1]) + suffix
+									if idx1 != idx2 && gen.meetsPolicy([]byte(passphrase)) {
+										passphrases[passphrase] = true
+									}
+								}
+							}
+						}
+					}
+				}
+				assert.Equal(t, fmt.Sprint(len(passphrases)), g.Entropy().Keyspace.String())
+			})
+		}
+	})
+
+	t.Run("with numbers, symbols and separators", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			rules     []Rule
+			numShapes int64
+		}{
+			"suffix": {
+				rules:     []Rule{WithNumNumbers(2)},
+				numShapes: 3 * 10 * 10,
+			},
+			"tokens": {
+				rules: []Rule{
+					WithNumNumbers(2), WithNumberPlacement(PlacementToken),
+					WithSymbols("!@", 1), WithSymbolPlacement(PlacementToken),
+				},
+				numShapes: 60 * 10 * 10 * 2,
+			},
+			"prefix and end": {
+				rules: []Rule{
+					WithNumDigits(2), WithNumberPlacement(PlacementPrefix),
+					WithSymbols("!@", 2), WithSymbolPlacement(PlacementEnd),
+				},
+				numShapes: 3 * 100 * 4,
+			},
+			"suffix and tokens": {
+				rules: []Rule{
+					WithNumNumbers(3),
+					WithSymbols("!@#", 1), WithSymbolPlacement(PlacementToken),
+				},
+				numShapes: 1000 * 4 * 3,
+			},
+			"random separators": {
+				rules:     []Rule{WithNumber(false), WithRandomSeparators("·•—")},
+				numShapes: 3 * 3,
+			},
+			"same random separator for all gaps": {
+				rules:     []Rule{WithNumber(false), WithRandomSeparators("·•—"), WithSameRandomSeparator(true)},
+				numShapes: 3,
+			},
+			"random separators with numbers as tokens": {
+				rules: []Rule{
+					WithNumNumbers(1), WithNumberPlacement(PlacementToken),
+					WithRandomSeparators("·•—"),
+				},
+				numShapes: 4 * 10 * 3 * 3 * 3,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				g, err := NewGenerator(append([]Rule{
+					WithCapitalizedWords(false),
+					WithDictionary(testDictionary(300, false)),
+					WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+				}, tc.rules...)...)
+				assert.Nil(t, err)
+				assert.Equal(t, fmt.Sprint(300*299*298*tc.numShapes), g.Entropy().Keyspace.String())
+
+				// every shape (the passphrase without its words) must be generated
+				words := regexp.MustCompile(`w[a-z]{3}`)
+				shapes := make(map[string]bool)
+				for idx := 0; idx < int(tc.numShapes)*20; idx++ {
+					passphrase, err := g.Generate()
+					assert.NoError(t, err)
+					shapes[words.ReplaceAllString(passphrase, "w")] = true
+				}
+				assert.Len(t, shapes, int(tc.numShapes))
+			})
+		}
+	})
+
+	t.Run("with length limits", func(t *testing.T) {
+		dict := testDictionary(300, true)
+		for name, tc := range map[string]struct {
+			rules []Rule
+			fits  func(length int) bool
+		}{
+			"max": {
+				rules: []Rule{WithMaxLength(12)},
+				fits:  func(length int) bool { return length <= 12 },
+			},
+			"min": {
+				rules: []Rule{WithMinLength(14)},
+				fits:  func(length int) bool { return length >= 14 },
+			},
+			"min and max": {
+				rules: []Rule{WithMinLength(11), WithMaxLength(13)},
+				fits:  func(length int) bool { return length >= 11 && length <= 13 },
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				g, err := NewGenerator(append([]Rule{
+					WithCapitalizedWords(false),
+					WithCasing(CasingRandomWordUpper),
+					WithDictionary(dict),
+					WithNumWords(2),
+					WithNumber(false),
+					WithWordLength(4, 8),
+				}, tc.rules...)...)
+				assert.Nil(t, err)
+
+				assert.Equal(t, fmt.Sprint(numFittingPassphrases(dict, tc.fits)), g.Entropy().Keyspace.String())
+			})
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		g, err := NewGenerator(WithDictionary(dictionaries.English()))
+		assert.Nil(t, err)
+		assert.Greater(t, g.Entropy().Bits, 50.0)
+	})
+}
+
+// numFittingPassphrases returns the number of distinct passphrases made up of
+// two distinct words from the dictionary, one of them upper-case, that fit.
+func numFittingPassphrases(dict []string, fits func(length int) bool) int {
+	passphrases := make(map[string]bool)
+	for _, word1 := range dict {
+		for _, word2 := range dict {
+			if word1 != word2 && fits(len(word1)+1+len(word2)) {
+				passphrases[strings.ToUpper(word1)+"-"+word2] = true
+				passphrases[word1+"-"+strings.ToUpper(word2)] = true
+			}
+		}
+	}
+	return len(passphrases)
+}
+
+func TestWithMinEntropy(t *testing.T) {
+	g, err := NewGenerator(
+		WithDictionary(dictionaries.English()),
+		WithMinEntropy(60),
+		WithNumWords(4),
+	)
+	assert.NotNil(t, g)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, g.Entropy().Bits, 60.0)
+
+	g, err = NewGenerator(
+		WithDictionary(dictionaries.English()),
+		WithMinEntropy(g.Entropy().Bits+0.01),
+		WithNumWords(4),
+	)
+	assert.Nil(t, g)
+	assert.Equal(t, ErrEntropyTooLow, err)
+}
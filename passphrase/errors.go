@@ -5,9 +5,22 @@ import (
 )
 
 var (
-	ErrBufferTooSmall     = fmt.Errorf("buffer is too small to hold the generated passphrase")
-	ErrDictionaryTooSmall = fmt.Errorf("dictionary cannot have less than %d words after word-length restrictions are applied", MinWordsInDictionary)
-	ErrNumWordsTooLarge   = fmt.Errorf("number of words cannot be more than %d", NumWordsMax)
-	ErrNumWordsTooSmall   = fmt.Errorf("number of words cannot be less than %d", NumWordsMin)
-	ErrWordLengthInvalid  = fmt.Errorf("word-length rule invalid")
+	ErrBufferTooSmall            = fmt.Errorf("buffer is too small to hold the generated passphrase")
+	ErrCasingInvalid             = fmt.Errorf("casing rule invalid")
+	ErrDiceRollsInvalid          = fmt.Errorf("dice rolls invalid")
+	ErrDicewareDictionaryInvalid = fmt.Errorf("dictionary is not a Diceware word list with 6^n words in the order of their dice rolls")
+	ErrDicewareRuleUnsupported   = fmt.Errorf("diceware passphrases cannot have words filtered by length, excluded or cased at random, random numbers, symbols or separators injected, or a length limit or policy")
+	ErrDictionaryTooSmall        = fmt.Errorf("dictionary cannot have less than %d words after word-length restrictions are applied", MinWordsInDictionary)
+	ErrEntropyTooLow             = fmt.Errorf("entropy of the passphrases is lower than the minimum requested")
+	ErrLengthInvalid             = fmt.Errorf("length rule invalid")
+	ErrLengthUnreachable         = fmt.Errorf("passphrases cannot fit within the length limits")
+	ErrNumberRuleInvalid         = fmt.Errorf("number rule invalid")
+	ErrNumWordsTooLarge          = fmt.Errorf("number of words cannot be more than %d", NumWordsMax)
+	ErrNumWordsTooSmall          = fmt.Errorf("number of words cannot be less than %d", NumWordsMin)
+	ErrPolicyInvalid             = fmt.Errorf("policy rule invalid")
+	ErrPolicyNotMet              = fmt.Errorf("failed to generate a passphrase meeting the policy")
+	ErrPolicyUnsatisfiable       = fmt.Errorf("passphrases cannot meet the policy")
+	ErrSeparatorRuleInvalid      = fmt.Errorf("separator rule invalid")
+	ErrSymbolRuleInvalid         = fmt.Errorf("symbol rule invalid")
+	ErrWordLengthInvalid         = fmt.Errorf("word-length rule invalid")
 )
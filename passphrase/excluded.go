@@ -0,0 +1,88 @@
+package passphrase
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ExcludedSubstringsError is returned when every passphrase generated within
+// the retry budget contained one of the excluded substrings.
+type ExcludedSubstringsError struct {
+	Attempts   int
+	Substrings []string
+}
+
+// Error returns the number of attempts made, and the excluded substrings.
+func (e *ExcludedSubstringsError) Error() string {
+	return fmt.Sprintf("failed to generate a passphrase without the excluded substrings %q in %d attempts",
+		e.Substrings, e.Attempts)
+}
+
+// newExcluded returns the excluded substrings as (NFC normalized) bytes, in
+// lower-case if the case is to be ignored.
+func (g *generator) newExcluded() [][]byte {
+	var rsp [][]byte
+	for _, substring := range g.excludedSubstrings {
+		substring = norm.NFC.String(substring)
+		if g.excludeIgnoreCase {
+			substring = strings.ToLower(substring)
+		}
+		rsp = append(rsp, []byte(substring))
+	}
+	return rsp
+}
+
+// containsExcluded returns true if the passphrase contains any of the excluded
+// substrings.
+func (g *generator) containsExcluded(passphrase []byte) bool {
+	for _, substring := range g.excluded {
+		if !g.excludeIgnoreCase {
+			if bytes.Contains(passphrase, substring) {
+				return true
+			}
+			continue
+		}
+
+		for offset := 0; offset < len(passphrase); {
+			if hasLowerPrefix(passphrase[offset:], substring) {
+				return true
+			}
+			_, size := utf8.DecodeRune(passphrase[offset:])
+			offset += size
+		}
+	}
+	return false
+}
+
+// wordContainsExcluded returns true if the word contains any of the excluded
+// substrings in any case it may be generated in.
+func (g *generator) wordContainsExcluded(word string) bool {
+	if g.casing == CasingNone {
+		return g.containsExcluded([]byte(word))
+	}
+	for _, variant := range casedWordVariants(word) {
+		if g.containsExcluded([]byte(variant)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLowerPrefix returns true if b starts with the lower-case prefix when
+// ignoring case.
+func hasLowerPrefix(b []byte, prefix []byte) bool {
+	for len(prefix) > 0 {
+		r, size := utf8.DecodeRune(prefix)
+		r2, size2 := utf8.DecodeRune(b)
+		if size2 == 0 || unicode.ToLower(r2) != r {
+			return false
+		}
+		prefix, b = prefix[size:], b[size2:]
+	}
+	return true
+}
@@ -1,11 +1,21 @@
 package passphrase
 
 import (
+	"fmt"
 	"slices"
-	"unicode"
+	"strings"
 	"unicode/utf8"
 
+	"github.com/jedib0t/go-passwords/entropy"
 	"github.com/jedib0t/go-passwords/rng"
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// maxGenerateAttempts is the number of times GenerateTo tries to build a
+	// passphrase without any of the excluded substrings before giving up.
+	maxGenerateAttempts = 1000
 )
 
 const (
@@ -15,22 +25,47 @@ const (
 )
 
 type Generator interface {
+	// Entropy returns the exact number of distinct passphrases that can be
+	// generated, and the bits of entropy it translates to. Excluded
+	// substrings spanning multiple words are not accounted for.
+	Entropy() entropy.Stats
 	// Generate returns a randomly generated password.
 	Generate() (string, error)
 	// GenerateTo generates a password and writes it to the provided buffer.
-	// It returns the number of bytes written or an error.
+	// It returns the number of bytes written or an error; with a password
+	// policy, this may be ErrPolicyNotMet with a negligible probability.
 	GenerateTo(buf []byte) (int, error)
 }
 
 type generator struct {
-	capitalize    bool
-	dictionary    []string
-	dictionaryLen int
-	separator     string
-	numWords      int
-	withNumber    bool
-	wordLenMin    int
-	wordLenMax    int
+	capitalize         bool
+	casedWords         [numWordCases][]string
+	casing             Casing
+	diceware           bool
+	dictionary         []string
+	dictionaryLen      int
+	excluded           [][]byte
+	excludeIgnoreCase  bool
+	excludedSubstrings []string
+	fittingWords       *fittingWords
+	lenMax             int
+	lenMin             int
+	minEntropy         float64
+	numbers            injection
+	policy             charClassCounts
+	numDice            int
+	separator          string
+	numWords           int
+	randomSeparators   bool
+	rng                rng.Source
+	sameSeparator      bool
+	separators         []rune
+	symbols            injection
+	wordBytesMax       int
+	wordClasses        map[wordClass]int
+	wordLengths        []int
+	wordLenMin         int
+	wordLenMax         int
 }
 
 // NewGenerator returns a password generator that implements the Generator
@@ -40,13 +75,13 @@ func NewGenerator(rules ...Rule) (Generator, error) {
 	for _, opt := range append(basicRules, rules...) {
 		opt(g)
 	}
+	g.excluded = g.newExcluded()
 	return g.sanitize()
 }
 
 // Generate returns a randomly generated password.
 func (g *generator) Generate() (string, error) {
-	// estimate the capacity needed: max word length * num words + separators + digit
-	buf := make([]byte, g.wordLenMax*g.numWords+len(g.separator)*(g.numWords-1)+1)
+	buf := make([]byte, g.maxBytes())
 	n, err := g.GenerateTo(buf)
 	if err != nil {
 		return "", err
@@ -57,44 +92,123 @@ func (g *generator) Generate() (string, error) {
 // GenerateTo generates a password and writes it to the provided buffer.
 // It returns the number of bytes written or an error.
 func (g *generator) GenerateTo(buf []byte) (int, error) {
-	// inject a random number after one of the words if asked for
-	wordForDigitSuffixIdx, digit := -1, 0
-	if g.withNumber {
-		var err error
-		if wordForDigitSuffixIdx, err = rng.IntN(g.numWords); err != nil {
-			return 0, err
+	policyNotMet := false
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		n, err := g.generateTo(buf)
+		if err != nil {
+			return n, err
 		}
-		if digit, err = rng.IntN(10); err != nil {
-			return 0, err
+		if g.containsExcluded(buf[:n]) {
+			policyNotMet = false
+			continue
+		}
+		if policyNotMet = !g.meetsPolicy(buf[:n]); !policyNotMet {
+			return n, nil
 		}
 	}
+	if policyNotMet {
+		return 0, fmt.Errorf("%w in %d attempts", ErrPolicyNotMet, maxGenerateAttempts)
+	}
+	return 0, &ExcludedSubstringsError{Attempts: maxGenerateAttempts, Substrings: g.excludedSubstrings}
+}
+
+func (g *generator) generateTo(buf []byte) (int, error) {
+	// pick where the numbers and symbols go if asked for
+	var l layout
+	if err := g.pickLayout(&l); err != nil {
+		return 0, err
+	}
 
-	// Select unique word indices using rejection sampling
 	var wordIndices [NumWordsMax]int
-	for i := 0; i < g.numWords; i++ {
+	if err := g.pickFittingWords(wordIndices[:g.numWords]); err != nil {
+		return 0, err
+	}
+	var wordCases [NumWordsMax]wordCase
+	if err := g.pickCases(wordCases[:g.numWords]); err != nil {
+		return 0, err
+	}
+	return g.writeWordsToBuf(buf, &l, wordIndices[:g.numWords], wordCases[:g.numWords])
+}
+
+// maxBytes returns the maximum number of bytes in a passphrase: the longest
+// words, the separators between them and the numbers/symbols injected as
+// tokens of their own, and the numbers/symbols themselves.
+func (g *generator) maxBytes() int {
+	numTokens := g.numWords + g.numbers.numTokens() + g.symbols.numTokens()
+	return g.wordBytesMax*g.numWords + g.maxSeparatorBytes()*(numTokens-1) + g.numbers.maxBytes() + g.symbols.maxBytes()
+}
+
+// pickWords fills the given slice with the indices of randomly picked words.
+func (g *generator) pickWords(wordIndices []int) error {
+	for i := range wordIndices {
+		// diceware words are picked independently just like with dice
+		if g.diceware {
+			wordIndex, err := g.rng.IntN(g.dictionaryLen)
+			if err != nil {
+				return err
+			}
+			wordIndices[i] = wordIndex
+			continue
+		}
+
+		// select unique word indices using rejection sampling
 		wordIndex, err := g.getUniqueWordIndex(wordIndices[:i])
 		if err != nil {
-			return 0, err
+			return err
 		}
 		wordIndices[i] = wordIndex
 	}
+	return nil
+}
 
-	// append words to the buffer
+// writeWordsToBuf writes the words at the given indices in the given cases to
+// the buffer, along with the separators, and the numbers and symbols where
+// the layout has them.
+func (g *generator) writeWordsToBuf(buf []byte, l *layout, wordIndices []int, wordCases []wordCase) (int, error) {
 	offset := 0
-	for idx := 0; idx < g.numWords; idx++ {
-		err := g.writeWordToBuf(buf, &offset, g.dictionary[wordIndices[idx]],
-			idx == wordForDigitSuffixIdx, digit, idx < g.numWords-1)
+	if err := g.writeInjectionsToBuf(buf, &offset, l, PlacementStart, -1); err != nil {
+		return 0, err
+	}
+	separatorIdx, err := g.pickSeparator()
+	if err != nil {
+		return 0, err
+	}
+	wordIdx := 0
+	for idx := 0; idx < l.numTokens; idx++ {
+		if idx > 0 {
+			if err := g.writeSeparatorToBuf(buf, &offset, separatorIdx); err != nil {
+				return 0, err
+			}
+			if !g.sameSeparator {
+				if separatorIdx, err = g.pickSeparator(); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+		var err error
+		switch l.tokens[idx] {
+		case tokenNumber:
+			err = g.writeInjectionToBuf(buf, &offset, &g.numbers, 1)
+		case tokenSymbol:
+			err = g.writeInjectionToBuf(buf, &offset, &g.symbols, 1)
+		default:
+			err = g.writeWordToBuf(buf, &offset, l, wordIdx, g.word(wordIndices[wordIdx], wordCases[wordIdx]))
+			wordIdx++
+		}
 		if err != nil {
 			return 0, err
 		}
 	}
-
+	if err := g.writeInjectionsToBuf(buf, &offset, l, PlacementEnd, -1); err != nil {
+		return 0, err
+	}
 	return offset, nil
 }
 
 func (g *generator) getUniqueWordIndex(pickedIndices []int) (int, error) {
 	for {
-		wordIndex, err := rng.IntN(g.dictionaryLen)
+		wordIndex, err := g.rng.IntN(g.dictionaryLen)
 		if err != nil {
 			return 0, err
 		}
@@ -112,65 +226,202 @@ func (g *generator) getUniqueWordIndex(pickedIndices []int) (int, error) {
 	}
 }
 
-func (g *generator) writeWordToBuf(buf []byte, offset *int, word string, addDigit bool, digit int, addSeparator bool) error {
-	if *offset+len(word) > len(buf) {
+// writeWordToBuf writes the word to the buffer, along with the numbers and
+// symbols the layout has before or after it.
+func (g *generator) writeWordToBuf(buf []byte, offset *int, l *layout, wordIdx int, word string) error {
+	if !l.numbers[wordIdx] && !l.symbols[wordIdx] {
+		return writeStringToBuf(buf, offset, word)
+	}
+
+	if err := g.writeInjectionsToBuf(buf, offset, l, PlacementPrefix, wordIdx); err != nil {
+		return err
+	}
+	if err := writeStringToBuf(buf, offset, word); err != nil {
+		return err
+	}
+	return g.writeInjectionsToBuf(buf, offset, l, PlacementSuffix, wordIdx)
+}
+
+// randomIndex returns a random number in [0, n).
+func (g *generator) randomIndex(n int) (int, error) {
+	if n == 1 {
+		return 0, nil
+	}
+	rsp, err := g.rng.IntN(n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate random number: %w", err)
+	}
+	return rsp, nil
+}
+
+func writeStringToBuf(buf []byte, offset *int, s string) error {
+	if *offset+len(s) > len(buf) {
 		return ErrBufferTooSmall
 	}
-	*offset += copy(buf[*offset:], word)
+	*offset += copy(buf[*offset:], s)
+	return nil
+}
 
-	if addDigit {
-		if *offset+1 > len(buf) {
-			return ErrBufferTooSmall
-		}
-		buf[*offset] = '0' + byte(digit)
-		(*offset)++
+func (g *generator) sanitize() (Generator, error) {
+	if g.casing < CasingNone || g.casing > CasingRandom {
+		return nil, ErrCasingInvalid
+	}
+
+	if err := g.sanitizeWords(); err != nil {
+		return nil, err
+	}
+
+	// check if the dictionary is too small; Diceware word lists only have to
+	// have 6^n words, as their words may be picked more than once
+	if !g.diceware && (g.dictionaryLen < g.numWords || g.dictionaryLen < MinWordsInDictionary) {
+		return nil, ErrDictionaryTooSmall
 	}
 
-	if addSeparator {
-		if *offset+len(g.separator) > len(buf) {
-			return ErrBufferTooSmall
+	// check if the number of words is too small or too large
+	if g.numWords < NumWordsMin {
+		return nil, ErrNumWordsTooSmall
+	}
+	if g.numWords > NumWordsMax {
+		return nil, ErrNumWordsTooLarge
+	}
+
+	// check if the numbers, symbols and separators can be injected
+	if err := g.sanitizeInjections(); err != nil {
+		return nil, err
+	}
+
+	// check if the passphrases can fit within the length limits
+	if err := g.sanitizeLength(); err != nil {
+		return nil, err
+	}
+
+	// check if the passphrases can meet the policy
+	if err := g.sanitizePolicy(); err != nil {
+		return nil, err
+	}
+
+	// check if the configuration is strong enough
+	if g.minEntropy > 0 && g.Entropy().Bits < g.minEntropy {
+		return nil, ErrEntropyTooLow
+	}
+	return g, nil
+}
+
+// sanitizeWords prepares the words in the dictionary for generating the
+// passphrases, and for the entropy calculations.
+func (g *generator) sanitizeWords() error {
+	sanitizeDictionary := g.sanitizeDictionary
+	if g.diceware {
+		sanitizeDictionary = g.sanitizeDicewareDictionary
+	}
+	if err := sanitizeDictionary(); err != nil {
+		return err
+	}
+	g.dictionaryLen = len(g.dictionary)
+	g.sanitizeCasing()
+	g.wordBytesMax = g.maxWordBytes()
+	g.wordLengths = nil
+	if g.isLengthLimited() {
+		g.wordLengths = make([]int, g.dictionaryLen)
+		for idx, word := range g.dictionary {
+			g.wordLengths[idx] = wordLength(word)
 		}
-		*offset += copy(buf[*offset:], g.separator)
 	}
+	g.sanitizeWordClasses()
+	return nil
+}
 
+// sanitizeInjections ensures the numbers, symbols and random separators can be
+// injected into the passphrases.
+func (g *generator) sanitizeInjections() error {
+	if err := g.numbers.sanitize(g.numWords, ErrNumberRuleInvalid); err != nil {
+		return err
+	}
+	if err := g.symbols.sanitize(g.numWords, ErrSymbolRuleInvalid); err != nil {
+		return err
+	}
+	if g.randomSeparators && len(g.separators) == 0 {
+		return ErrSeparatorRuleInvalid
+	}
 	return nil
 }
 
-func (g *generator) sanitize() (Generator, error) {
+// maxWordBytes returns the size in bytes of the longest word, in any case it
+// may be generated in.
+func (g *generator) maxWordBytes() int {
+	rsp := 0
+	for _, words := range append([][]string{g.dictionary}, g.casedWords[:]...) {
+		for _, word := range words {
+			rsp = max(rsp, len(word))
+		}
+	}
+	return rsp
+}
+
+func (g *generator) sanitizeDictionary() error {
 	// check if the word length is valid
 	if g.wordLenMin < 1 || g.wordLenMin > g.wordLenMax {
-		return nil, ErrWordLengthInvalid
+		return ErrWordLengthInvalid
+	}
+
+	// normalize the words in a copy of the dictionary, so that words typed
+	// differently but looking the same (like "café" with a combining accent)
+	// are measured and de-duplicated the same way
+	g.dictionary = slices.Clone(g.dictionary)
+	for idx, word := range g.dictionary {
+		g.dictionary[idx] = norm.NFC.String(word)
 	}
 
 	// remove words that are too-short & too-long
 	g.dictionary = slices.DeleteFunc(g.dictionary, func(word string) bool {
-		return len(word) < g.wordLenMin || len(word) > g.wordLenMax
+		wordLen := wordLength(word)
+		return wordLen < g.wordLenMin || wordLen > g.wordLenMax
 	})
+
+	// capitalize (or lower-case) all words in the dictionary ahead of time;
+	// this is done before removing duplicates as "foo" and "Foo" would both
+	// end up as "Foo"
+	g.caseWords()
+	// remove words with excluded substrings in them, in any case they may be
+	// generated in
+	g.dictionary = slices.DeleteFunc(g.dictionary, g.wordContainsExcluded)
 	slices.Sort(g.dictionary)
 	g.dictionary = slices.Compact(g.dictionary)
-	g.dictionaryLen = len(g.dictionary)
-
-	// check if the dictionary is too small
-	if g.dictionaryLen < g.numWords || g.dictionaryLen < MinWordsInDictionary {
-		return nil, ErrDictionaryTooSmall
-	}
+	return nil
+}
 
-	// capitalize all words in the dictionary ahead of time
+// capitalizeWords capitalizes the first character (grapheme cluster) of all
+// the words in the dictionary if asked for.
+func (g *generator) capitalizeWords() {
 	if g.capitalize {
-		for idx := range g.dictionary {
-			r, size := utf8.DecodeRuneInString(g.dictionary[idx])
-			if r != utf8.RuneError {
-				g.dictionary[idx] = string(unicode.ToUpper(r)) + g.dictionary[idx][size:]
-			}
+		for idx, word := range g.dictionary {
+			g.dictionary[idx] = capitalizeWord(word)
 		}
 	}
+}
 
-	// check if the number of words is too small or too large
-	if g.numWords < NumWordsMin {
-		return nil, ErrNumWordsTooSmall
+// capitalizeWord returns the word with its first character (grapheme cluster)
+// converted to title case.
+func capitalizeWord(word string) string {
+	// fast path for an ASCII character not combined with the one following it
+	if word == "" || word[0] < utf8.RuneSelf && (len(word) == 1 || word[1] < utf8.RuneSelf) {
+		if word != "" && 'a' <= word[0] && word[0] <= 'z' {
+			return string(word[0]-'a'+'A') + word[1:]
+		}
+		return word
 	}
-	if g.numWords > NumWordsMax {
-		return nil, ErrNumWordsTooLarge
+
+	first, rest, _, _ := uniseg.FirstGraphemeClusterInString(word, -1)
+	return norm.NFC.String(strings.ToTitle(first) + rest)
+}
+
+// wordLength returns the number of user-perceived characters (grapheme
+// clusters) in the word.
+func wordLength(word string) int {
+	for idx := 0; idx < len(word); idx++ {
+		if word[idx] >= utf8.RuneSelf {
+			return uniseg.GraphemeClusterCount(word)
+		}
 	}
-	return g, nil
+	return len(word)
 }
@@ -1,9 +1,12 @@
 package passphrase
 
 import (
+	"math/rand"
 	"testing"
 
+	"github.com/jedib0t/go-passwords/charset"
 	"github.com/jedib0t/go-passwords/passphrase/dictionaries"
+	"github.com/jedib0t/go-passwords/rng"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,6 +47,18 @@ func TestNewGenerator_ErrorCases(t *testing.T) {
 		assert.Equal(t, ErrDictionaryTooSmall, err)
 	})
 
+	t.Run("entropy too low", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithDictionary(dictionaries.English()),
+			WithMinEntropy(60),
+			WithNumWords(2),
+			WithNumber(false),
+		)
+		assert.Nil(t, g)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrEntropyTooLow, err)
+	})
+
 	t.Run("num words too small", func(t *testing.T) {
 		g, err := NewGenerator(
 			WithDictionary(dictionaries.English()),
@@ -63,6 +78,33 @@ func TestNewGenerator_ErrorCases(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.Equal(t, ErrNumWordsTooLarge, err)
 	})
+
+	for name, tc := range map[string]struct {
+		rules []Rule
+		err   error
+	}{
+		"negative numbers":               {[]Rule{WithNumNumbers(-1)}, ErrNumberRuleInvalid},
+		"too many numbers":               {[]Rule{WithNumNumbers(NumWordsMax + 1), WithNumberPlacement(PlacementEnd)}, ErrNumberRuleInvalid},
+		"more numbers than words":        {[]Rule{WithNumNumbers(4)}, ErrNumberRuleInvalid},
+		"no digits":                      {[]Rule{WithNumDigits(0)}, ErrNumberRuleInvalid},
+		"invalid number placement":       {[]Rule{WithNumberPlacement(PlacementEnd + 1)}, ErrNumberRuleInvalid},
+		"no symbols in the charset":      {[]Rule{WithSymbols("", 1)}, ErrSymbolRuleInvalid},
+		"more symbols than words":        {[]Rule{WithSymbols(charset.Symbols, 4)}, ErrSymbolRuleInvalid},
+		"invalid symbol placement":       {[]Rule{WithSymbols(charset.Symbols, 1), WithSymbolPlacement(-1)}, ErrSymbolRuleInvalid},
+		"no random separators":           {[]Rule{WithRandomSeparators("")}, ErrSeparatorRuleInvalid},
+		"negative max length":            {[]Rule{WithMaxLength(-1)}, ErrLengthInvalid},
+		"negative min length":            {[]Rule{WithMinLength(-1)}, ErrLengthInvalid},
+		"min length over max":            {[]Rule{WithMinLength(30), WithMaxLength(20)}, ErrLengthInvalid},
+		"separators over max length":     {[]Rule{WithNumWords(10), WithMaxLength(8)}, ErrLengthUnreachable},
+		"shortest words over max length": {[]Rule{WithNumWords(5), WithWordLength(6, 8), WithMaxLength(34)}, ErrLengthUnreachable},
+		"longest words under min length": {[]Rule{WithNumWords(3), WithWordLength(4, 7), WithMinLength(25)}, ErrLengthUnreachable},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewGenerator(tc.rules...)
+			assert.Nil(t, g)
+			assert.Equal(t, tc.err, err)
+		})
+	}
 }
 
 func TestGenerator_sanitize_EdgeCases(t *testing.T) {
@@ -88,6 +130,31 @@ func TestGenerator_sanitize_EdgeCases(t *testing.T) {
 }
 
 func TestGenerator_Generate_EdgeCases(t *testing.T) {
+	t.Run("length limits favour no total length", func(t *testing.T) {
+		// the pairs of 4 and 5 letter words (3600 + 3600) outnumber the pairs
+		// of 4 letter words (60 * 59) and fit just as well
+		g, err := NewGenerator(
+			WithCapitalizedWords(false),
+			WithDictionary(testDictionary(300, true)),
+			WithMaxLength(11),
+			WithNumber(false),
+			WithNumWords(2),
+			WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+			WithWordLength(4, 8),
+		)
+		assert.Nil(t, err)
+
+		lengths := make(map[int]int)
+		for idx := 0; idx < 10000; idx++ {
+			passphrase, err := g.Generate()
+			assert.NoError(t, err)
+			lengths[len(passphrase)]++
+		}
+		assert.InDelta(t, 3540.0/21480*10000, lengths[9], 200)
+		assert.InDelta(t, 7200.0/21480*10000, lengths[10], 200)
+		assert.InDelta(t, 10740.0/21480*10000, lengths[11], 200)
+	})
+
 	t.Run("without number", func(t *testing.T) {
 		g, err := NewGenerator(
 			WithDictionary(dictionaries.English()),
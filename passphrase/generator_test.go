@@ -1,51 +1,391 @@
 package passphrase
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
+	"github.com/jedib0t/go-passwords/charset"
 	"github.com/jedib0t/go-passwords/passphrase/dictionaries"
+	"github.com/jedib0t/go-passwords/rng"
 	"github.com/stretchr/testify/assert"
 )
 
+// testDictionary returns a dictionary of n words made up of "w" and 3
+// lower-case letters ("waaa", "waab", ...), so that they can be told apart from
+// the numbers and symbols. With mixedLengths, every tenth word is made up of
+// digits instead, and the words are padded with up to 4 "z"s to be 4 to 8
+// characters long.
+func testDictionary(n int, mixedLengths bool) []string {
+	rsp := make([]string, 0, n)
+	for idx := 0; idx < n; idx++ {
+		word := fmt.Sprintf("w%c%c%c", 'a'+idx/676, 'a'+idx/26%26, 'a'+idx%26)
+		if mixedLengths {
+			if idx%10 == 0 {
+				word = fmt.Sprintf("%04d", idx)
+			}
+			word += strings.Repeat("z", idx%5)
+		}
+		rsp = append(rsp, word)
+	}
+	return rsp
+}
+
+// separatorsOf returns the distinct separators between the words of a
+// passphrase made up of words from testDictionary.
+func separatorsOf(passphrase string) map[string]bool {
+	gaps := regexp.MustCompile(`w[a-z]{3}`).Split(passphrase, -1)
+	rsp := make(map[string]bool)
+	for _, separator := range gaps[1 : len(gaps)-1] {
+		rsp[separator] = true
+	}
+	return rsp
+}
+
 func TestGenerator_Generate(t *testing.T) {
+	t.Run("english", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCapitalizedWords(true),
+			WithDictionary(dictionaries.English()),
+			WithNumWords(3),
+			WithNumber(true),
+			WithSeparator("-"),
+			WithWordLength(4, 6),
+		)
+		assert.NotNil(t, g)
+		assert.Nil(t, err)
+
+		for idx := 0; idx < 1000; idx++ {
+			passphrase, err := g.Generate()
+			assert.NoError(t, err)
+			assert.NotEmpty(t, passphrase)
+
+			// Verify structure: should have 3 words separated by "-"
+			words := strings.Split(passphrase, "-")
+			assert.Equal(t, 3, len(words), "passphrase should have 3 words: %s", passphrase)
+
+			// Verify each word is capitalized and has a number in one of them
+			hasNumber := false
+			for _, word := range words {
+				// Check that word starts with uppercase
+				assert.True(t, len(word) > 0, "word should not be empty")
+				assert.True(t, word[0] >= 'A' && word[0] <= 'Z', "word should start with uppercase: %s", word)
+				// Check word length is between 4 and 6 (plus possibly a digit)
+				assert.True(t, len(word) >= 4 && len(word) <= 7, "word length should be 4-7 (including possible digit): %s", word)
+
+				// Check if word contains a digit
+				for _, r := range word {
+					if r >= '0' && r <= '9' {
+						hasNumber = true
+						break
+					}
+				}
+			}
+			assert.True(t, hasNumber, "passphrase should contain at least one number: %s", passphrase)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		rules         []Rule
+		pattern       string
+		numSeparators int
+		sameSeparator bool
+		lenMin        int
+		lenMax        int
+	}{
+		"default": {
+			pattern: `^w[a-z]{3}[0-9]?-w[a-z]{3}[0-9]?-w[a-z]{3}[0-9]?$`,
+		},
+		"two digits as prefix": {
+			rules:   []Rule{WithNumDigits(2), WithNumberPlacement(PlacementPrefix)},
+			pattern: `^([0-9]{2})?w[a-z]{3}-([0-9]{2})?w[a-z]{3}-([0-9]{2})?w[a-z]{3}$`,
+		},
+		"numbers as tokens": {
+			rules:   []Rule{WithNumNumbers(2), WithNumberPlacement(PlacementToken)},
+			pattern: `^((w[a-z]{3}|[0-9])-){4}(w[a-z]{3}|[0-9])$`,
+		},
+		"more numbers than words as tokens": {
+			rules:   []Rule{WithNumNumbers(4), WithNumberPlacement(PlacementToken)},
+			pattern: `^((w[a-z]{3}|[0-9])-){6}(w[a-z]{3}|[0-9])$`,
+		},
+		"numbers at the start, symbols at the end": {
+			rules: []Rule{
+				WithNumDigits(3), WithNumberPlacement(PlacementStart),
+				WithSymbols("!@", 2), WithSymbolPlacement(PlacementEnd),
+			},
+			pattern: `^[0-9]{3}w[a-z]{3}-w[a-z]{3}-w[a-z]{3}[!@]{2}$`,
+		},
+		"numbers and symbols after the same word": {
+			rules:   []Rule{WithNumNumbers(3), WithSymbols("€", 3)},
+			pattern: `^w[a-z]{3}[0-9]€-w[a-z]{3}[0-9]€-w[a-z]{3}[0-9]€$`,
+		},
+		"symbols only": {
+			rules:   []Rule{WithNumber(false), WithSymbols(charset.Symbols, 1), WithSymbolPlacement(PlacementToken)},
+			pattern: `^((w[a-z]{3}|[!@#$%^&*])-){3}(w[a-z]{3}|[!@#$%^&*])$`,
+		},
+		"random separators": {
+			rules:         []Rule{WithNumber(false), WithNumWords(4), WithRandomSeparators(charset.Numbers + charset.Symbols)},
+			pattern:       `^w[a-z]{3}[0-9!@#$%^&*]w[a-z]{3}[0-9!@#$%^&*]w[a-z]{3}[0-9!@#$%^&*]w[a-z]{3}$`,
+			numSeparators: 18,
+		},
+		"multi-byte random separators": {
+			rules:         []Rule{WithNumber(false), WithNumWords(4), WithRandomSeparators("·•—")},
+			pattern:       `^w[a-z]{3}[·•—]w[a-z]{3}[·•—]w[a-z]{3}[·•—]w[a-z]{3}$`,
+			numSeparators: 3,
+		},
+		"same random separator for all gaps": {
+			rules:         []Rule{WithNumber(false), WithNumWords(4), WithRandomSeparators("·•—"), WithSameRandomSeparator(true)},
+			pattern:       `^w[a-z]{3}[·•—]w[a-z]{3}[·•—]w[a-z]{3}[·•—]w[a-z]{3}$`,
+			numSeparators: 3,
+			sameSeparator: true,
+		},
+		"random separators overridden by a fixed one": {
+			rules:         []Rule{WithNumber(false), WithNumWords(4), WithRandomSeparators("·•—"), WithSeparator("_")},
+			pattern:       `^w[a-z]{3}_w[a-z]{3}_w[a-z]{3}_w[a-z]{3}$`,
+			numSeparators: 1,
+		},
+		"max length": {
+			rules:  []Rule{WithDictionary(dictionaries.English()), WithMaxLength(32), WithNumWords(5), WithWordLength(4, 10)},
+			lenMax: 32,
+		},
+		"min length": {
+			rules:  []Rule{WithDictionary(dictionaries.English()), WithMinLength(40), WithNumWords(5), WithWordLength(4, 10)},
+			lenMin: 40,
+		},
+		"min and max length": {
+			rules:  []Rule{WithDictionary(dictionaries.English()), WithMinLength(22), WithMaxLength(23), WithWordLength(4, 10)},
+			lenMin: 22,
+			lenMax: 23,
+		},
+		"max length with everything else": {
+			rules: []Rule{
+				WithCasing(CasingRandom),
+				WithDictionary(dictionaries.English()),
+				WithMaxLength(36),
+				WithNumDigits(2),
+				WithNumNumbers(2),
+				WithNumWords(4),
+				WithRandomSeparators("·•—"),
+				WithSymbols(charset.Symbols, 1),
+				WithSymbolPlacement(PlacementToken),
+				WithWordLength(4, 10),
+			},
+			lenMax: 36,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewGenerator(append([]Rule{
+				WithCapitalizedWords(false),
+				WithDictionary(testDictionary(300, false)),
+				WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+			}, tc.rules...)...)
+			assert.Nil(t, err)
+
+			pattern := regexp.MustCompile(tc.pattern)
+			separators := make(map[string]bool)
+			lengths := make(map[int]bool)
+			for idx := 0; idx < 100; idx++ {
+				passphrase, err := g.Generate()
+				assert.NoError(t, err)
+				assert.Regexp(t, pattern, passphrase)
+
+				if tc.numSeparators > 0 {
+					gaps := separatorsOf(passphrase)
+					if tc.sameSeparator {
+						assert.Len(t, gaps, 1, passphrase)
+					}
+					maps.Copy(separators, gaps)
+				}
+
+				length := utf8.RuneCountInString(passphrase)
+				assert.GreaterOrEqual(t, length, tc.lenMin, passphrase)
+				if tc.lenMax > 0 {
+					assert.LessOrEqual(t, length, tc.lenMax, passphrase)
+				}
+				lengths[length] = true
+			}
+			if tc.numSeparators > 0 {
+				assert.Len(t, separators, tc.numSeparators)
+			}
+			if tc.lenMin+tc.lenMax > 0 {
+				assert.Greater(t, len(lengths), 1)
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_WithLanguages(t *testing.T) {
+	for _, language := range dictionaries.Languages() {
+		t.Run(language, func(t *testing.T) {
+			dictionary, err := dictionaries.ByLanguage(language)
+			assert.NoError(t, err)
+			g, err := NewGenerator(
+				WithDictionary(dictionary),
+				WithNumber(false),
+				WithNumWords(4),
+				WithWordLength(4, 6),
+			)
+			assert.Nil(t, err)
+
+			for idx := 0; idx < 100; idx++ {
+				passphrase, err := g.Generate()
+				assert.NoError(t, err)
+
+				// words are as long as their characters, and not their bytes
+				words := strings.Split(passphrase, "-")
+				assert.Len(t, words, 4)
+				for _, word := range words {
+					wordLen := utf8.RuneCountInString(word)
+					assert.True(t, wordLen >= 4 && wordLen <= 6, word)
+				}
+			}
+		})
+	}
+
+	// multibyte words must fit in the buffer estimated by Generate
+	g, err := NewGenerator(
+		WithDictionary(dictionaries.Japanese()),
+		WithNumWords(32),
+		WithWordLength(4, 4),
+	)
+	assert.Nil(t, err)
+	passphrase, err := g.Generate()
+	assert.NoError(t, err)
+	assert.Equal(t, 32*4+31+1, utf8.RuneCountInString(passphrase))
+}
+
+func TestNewGenerator_WithUnicodeWords(t *testing.T) {
+	dictionary := []string{
+		"cafe\u0301",             // "café" with a combining accent
+		"caf\u00e9",              // "café" pre-composed
+		"e\u0301lan",             // "élan" with a combining accent
+		"\u01c6em",               // "ǆem", with a digraph that has a title case
+		"ok\U0001F44D\U0001F3FD", // "ok👍🏽", with an emoji and a skin tone modifier
+		"つなみ",
+		"toolongword",
+	}
+	for idx := 0; idx < 300; idx++ {
+		dictionary = append(dictionary, fmt.Sprintf("w%03d", idx))
+	}
+	original := slices.Clone(dictionary)
+
 	g, err := NewGenerator(
 		WithCapitalizedWords(true),
-		WithDictionary(dictionaries.English()),
-		WithNumWords(3),
-		WithNumber(true),
-		WithSeparator("-"),
-		WithWordLength(4, 6),
+		WithDictionary(dictionary),
+		WithWordLength(3, 5),
 	)
-	assert.NotNil(t, g)
 	assert.Nil(t, err)
+	assert.Equal(t, original, dictionary, "dictionary must not be modified")
+
+	words := g.(*generator).dictionary
+	assert.Len(t, words, 305)
+	assert.Contains(t, words, "Caf\u00e9")
+	assert.Contains(t, words, "\u00c9lan")
+	assert.Contains(t, words, "\u01c5em")
+	assert.Contains(t, words, "Ok\U0001F44D\U0001F3FD")
+	assert.Contains(t, words, "つなみ")
+	assert.NotContains(t, words, "Toolongword")
+	assert.Equal(t, 3, wordLength("ok\U0001F44D\U0001F3FD"))
+	assert.Equal(t, 4, wordLength("cafe\u0301"))
+
+	for idx := 0; idx < 100; idx++ {
+		passphrase, err := g.Generate()
+		assert.NoError(t, err)
+		assert.True(t, utf8.ValidString(passphrase))
+	}
+}
 
+func TestGenerator_Generate_WithExcludedSubstrings(t *testing.T) {
+	g, err := NewGenerator(
+		WithExcludedSubstrings([]string{"", "THE", "and"}),
+		WithExcludedSubstringsIgnoreCase(true),
+	)
+	assert.Nil(t, err)
 	for idx := 0; idx < 1000; idx++ {
 		passphrase, err := g.Generate()
 		assert.NoError(t, err)
-		assert.NotEmpty(t, passphrase)
-
-		// Verify structure: should have 3 words separated by "-"
-		words := strings.Split(passphrase, "-")
-		assert.Equal(t, 3, len(words), "passphrase should have 3 words: %s", passphrase)
-
-		// Verify each word is capitalized and has a number in one of them
-		hasNumber := false
-		for _, word := range words {
-			// Check that word starts with uppercase
-			assert.True(t, len(word) > 0, "word should not be empty")
-			assert.True(t, word[0] >= 'A' && word[0] <= 'Z', "word should start with uppercase: %s", word)
-			// Check word length is between 4 and 6 (plus possibly a digit)
-			assert.True(t, len(word) >= 4 && len(word) <= 7, "word length should be 4-7 (including possible digit): %s", word)
-
-			// Check if word contains a digit
-			for _, r := range word {
-				if r >= '0' && r <= '9' {
-					hasNumber = true
-					break
-				}
-			}
+		assert.NotContains(t, strings.ToLower(passphrase), "the")
+		assert.NotContains(t, strings.ToLower(passphrase), "and")
+	}
+
+	t.Run("across words", func(t *testing.T) {
+		dict := make([]string, 0, 300)
+		for idx := 0; idx < 300; idx++ {
+			dict = append(dict, fmt.Sprintf("word%03d", idx))
 		}
-		assert.True(t, hasNumber, "passphrase should contain at least one number: %s", passphrase)
+		g, err := NewGenerator(
+			WithCapitalizedWords(false),
+			WithDictionary(dict),
+			WithExcludedSubstrings([]string{"7word"}),
+			WithNumber(false),
+			WithNumWords(4),
+			WithSeparator(""),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint(300*299*298*297), g.Entropy().Keyspace.String())
+
+		for idx := 0; idx < 1000; idx++ {
+			passphrase, err := g.Generate()
+			assert.NoError(t, err)
+			assert.NotContains(t, passphrase, "7word")
+		}
+	})
+
+	t.Run("retry budget exhausted", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithExcludedSubstrings([]string{"-"}),
+		)
+		assert.Nil(t, err)
+
+		passphrase, err := g.Generate()
+		assert.Empty(t, passphrase)
+		var excludedErr *ExcludedSubstringsError
+		assert.True(t, errors.As(err, &excludedErr))
+		assert.Equal(t, maxGenerateAttempts, excludedErr.Attempts)
+		assert.EqualError(t, err, `failed to generate a passphrase without the excluded substrings ["-"] in 1000 attempts`)
+	})
+}
+
+func TestGenerator_Generate_WithRandomSource(t *testing.T) {
+	newGenerator := func() Generator {
+		g, err := NewGenerator(
+			WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+		)
+		assert.Nil(t, err)
+		return g
+	}
+
+	g1, g2 := newGenerator(), newGenerator()
+	for idx := 0; idx < 100; idx++ {
+		phrase1, err := g1.Generate()
+		assert.NoError(t, err)
+		phrase2, err := g2.Generate()
+		assert.NoError(t, err)
+		assert.Equal(t, phrase1, phrase2)
+	}
+
+	g, err := NewGenerator(WithRandomSource(nil))
+	assert.Nil(t, err)
+	phrase, err := g.Generate()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, phrase)
+}
+
+func TestGenerator_GenerateTo_NoAllocs(t *testing.T) {
+	for _, rules := range [][]Rule{
+		nil,
+		{WithNumNumbers(2), WithNumberPlacement(PlacementToken), WithSymbols(charset.Symbols, 2), WithSymbolPlacement(PlacementPrefix)},
+	} {
+		g, err := NewGenerator(rules...)
+		assert.Nil(t, err)
+		buf := make([]byte, 512)
+
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _, _ = g.GenerateTo(buf) }))
 	}
 }
@@ -0,0 +1,241 @@
+package passphrase
+
+import (
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-passwords/charset"
+)
+
+// Placement controls where the numbers or symbols are injected into the
+// passphrases.
+type Placement int
+
+const (
+	// PlacementSuffix injects them after randomly chosen words, no more than
+	// one per word ("Foo-Bar7-Baz").
+	PlacementSuffix Placement = iota
+	// PlacementPrefix injects them before randomly chosen words, no more than
+	// one per word ("Foo-7Bar-Baz").
+	PlacementPrefix
+	// PlacementToken injects them as tokens of their own at random positions
+	// among the words ("Foo-7-Bar-Baz").
+	PlacementToken
+	// PlacementStart injects them at the start of the passphrase
+	// ("7Foo-Bar-Baz").
+	PlacementStart
+	// PlacementEnd injects them at the end of the passphrase ("Foo-Bar-Baz7").
+	PlacementEnd
+)
+
+// injection describes the numbers or symbols injected into the passphrases.
+type injection struct {
+	count     int
+	placement Placement
+	runes     []rune
+	width     int
+}
+
+var (
+	digits = []rune(charset.Numbers)
+)
+
+// positions returns the number of distinct ways to position the injection in
+// a passphrase with the given number of words and tokens (words and the
+// numbers/symbols injected as tokens of their own); the number of tokens is
+// updated with the tokens injected.
+func (inj *injection) positions(numWords int, numTokens *int) *big.Int {
+	rsp := big.NewInt(1)
+	if inj.count == 0 {
+		return rsp
+	}
+
+	switch inj.placement {
+	case PlacementPrefix, PlacementSuffix:
+		rsp.Binomial(int64(numWords), int64(inj.count))
+	case PlacementToken:
+		*numTokens += inj.count
+		rsp.Binomial(int64(*numTokens), int64(inj.count))
+	}
+	return rsp
+}
+
+// maxBytes returns the maximum number of bytes the injection adds to a
+// passphrase.
+func (inj *injection) maxBytes() int {
+	runeBytesMax := 0
+	for _, r := range inj.runes {
+		runeBytesMax = max(runeBytesMax, utf8.RuneLen(r))
+	}
+	return inj.count * inj.width * runeBytesMax
+}
+
+// numTokens returns the number of tokens of their own the injection adds to
+// a passphrase.
+func (inj *injection) numTokens() int {
+	if inj.placement == PlacementToken {
+		return inj.count
+	}
+	return 0
+}
+
+// sanitize returns the given error if the injection is not possible in a
+// passphrase with the given number of words.
+func (inj *injection) sanitize(numWords int, err error) error {
+	if inj.count == 0 {
+		return nil
+	}
+	if inj.count < 0 || inj.count > NumWordsMax || inj.width < 1 || len(inj.runes) == 0 ||
+		inj.placement < PlacementSuffix || inj.placement > PlacementEnd {
+		return err
+	}
+	// no more than one number/symbol per word
+	if (inj.placement == PlacementPrefix || inj.placement == PlacementSuffix) && inj.count > numWords {
+		return err
+	}
+	return nil
+}
+
+// tokenKind is the kind of a token in a passphrase.
+type tokenKind uint8
+
+const (
+	tokenWord tokenKind = iota
+	tokenNumber
+	tokenSymbol
+)
+
+// layout describes where the numbers and symbols are injected into a
+// passphrase.
+type layout struct {
+	numTokens int
+	tokens    [3 * NumWordsMax]tokenKind
+	numbers   [NumWordsMax]bool
+	symbols   [NumWordsMax]bool
+}
+
+// pickLayout picks the positions of the numbers and symbols to be injected
+// into the passphrase, and marks them in the given (zero) layout.
+func (g *generator) pickLayout(l *layout) error {
+	l.numTokens = g.numWords + g.numbers.numTokens() + g.symbols.numTokens()
+	if l.numTokens > g.numWords {
+		if err := g.pickTokens(l); err != nil {
+			return err
+		}
+	}
+
+	for _, kind := range [...]tokenKind{tokenNumber, tokenSymbol} {
+		inj, marks := g.injection(kind, l)
+		if inj.count == 0 || (inj.placement != PlacementPrefix && inj.placement != PlacementSuffix) {
+			continue
+		}
+		if inj.count == 1 {
+			// any word will do, as in the default passphrases
+			position, err := g.randomIndex(g.numWords)
+			if err != nil {
+				return err
+			}
+			marks[position] = true
+			continue
+		}
+
+		var positions [NumWordsMax]int
+		for idx := 0; idx < g.numWords; idx++ {
+			positions[idx] = idx
+		}
+		if err := g.pickPositions(positions[:g.numWords], 0, inj.count); err != nil {
+			return err
+		}
+		for _, position := range positions[:inj.count] {
+			marks[position] = true
+		}
+	}
+	return nil
+}
+
+// pickTokens picks the tokens for the numbers and symbols injected as tokens
+// of their own.
+func (g *generator) pickTokens(l *layout) error {
+	var positions [len(l.tokens)]int
+	for idx := 0; idx < l.numTokens; idx++ {
+		positions[idx] = idx
+	}
+	picked := 0
+	for _, kind := range [...]tokenKind{tokenNumber, tokenSymbol} {
+		inj, _ := g.injection(kind, l)
+		count := inj.numTokens()
+		if err := g.pickPositions(positions[:l.numTokens], picked, count); err != nil {
+			return err
+		}
+		for _, position := range positions[picked : picked+count] {
+			l.tokens[position] = kind
+		}
+		picked += count
+	}
+	return nil
+}
+
+// injection returns the injection of the given kind, and where it goes in
+// the given layout when injected before or after the words.
+func (g *generator) injection(kind tokenKind, l *layout) (*injection, *[NumWordsMax]bool) {
+	if kind == tokenNumber {
+		return &g.numbers, &l.numbers
+	}
+	return &g.symbols, &l.symbols
+}
+
+// pickPositions moves count random positions from positions[picked:] to
+// positions[picked:picked+count] using a partial Fisher-Yates shuffle.
+func (g *generator) pickPositions(positions []int, picked int, count int) error {
+	for idx := picked; idx < picked+count; idx++ {
+		swapIdx, err := g.randomIndex(len(positions) - idx)
+		if err != nil {
+			return err
+		}
+		swapIdx += idx
+		positions[idx], positions[swapIdx] = positions[swapIdx], positions[idx]
+	}
+	return nil
+}
+
+// writeInjectionToBuf writes count random numbers/symbols of the injection to
+// the buffer.
+func (g *generator) writeInjectionToBuf(buf []byte, offset *int, inj *injection, count int) error {
+	for idx := 0; idx < count*inj.width; idx++ {
+		runeIdx, err := g.randomIndex(len(inj.runes))
+		if err != nil {
+			return err
+		}
+		r := inj.runes[runeIdx]
+		if *offset+utf8.RuneLen(r) > len(buf) {
+			return ErrBufferTooSmall
+		}
+		*offset += utf8.EncodeRune(buf[*offset:], r)
+	}
+	return nil
+}
+
+// writeInjectionsToBuf writes the numbers and symbols injected at the given
+// placement (before/after the word at wordIdx if marked in the layout, or at
+// the start/end of the passphrase when wordIdx is -1) to the buffer; numbers
+// go before symbols.
+func (g *generator) writeInjectionsToBuf(buf []byte, offset *int, l *layout, placement Placement, wordIdx int) error {
+	for _, kind := range [...]tokenKind{tokenNumber, tokenSymbol} {
+		inj, marks := g.injection(kind, l)
+		if inj.count == 0 || inj.placement != placement {
+			continue
+		}
+
+		count := inj.count
+		if wordIdx >= 0 {
+			if !marks[wordIdx] {
+				continue
+			}
+			count = 1
+		}
+		if err := g.writeInjectionToBuf(buf, offset, inj, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
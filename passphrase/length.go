@@ -0,0 +1,265 @@
+package passphrase
+
+import (
+	"crypto/rand"
+	"math/big"
+	"slices"
+
+	"github.com/jedib0t/go-passwords/rng"
+)
+
+// isLengthLimited returns true if the length of the passphrases is limited.
+func (g *generator) isLengthLimited() bool {
+	return g.lenMin > 0 || g.lenMax > 0
+}
+
+// extraLength returns the number of characters in a passphrase besides the
+// words: the separators, numbers and symbols.
+func (g *generator) extraLength() int {
+	numTokens := g.numWords + g.numbers.numTokens() + g.symbols.numTokens()
+	separatorLen := 1
+	if !g.randomSeparators {
+		separatorLen = wordLength(g.separator)
+	}
+	return separatorLen*(numTokens-1) + g.numbers.count*g.numbers.width + g.symbols.count
+}
+
+// wordsLenLimits returns the limits on the total length of the words in a
+// passphrase (none if 0).
+func (g *generator) wordsLenLimits() (int, int) {
+	if !g.isLengthLimited() {
+		return 0, 0
+	}
+	lenMin, lenMax := max(g.lenMin-g.extraLength(), 0), 0
+	if g.lenMax > 0 {
+		// -1 as 0 means no limit; no words can fit anyway
+		lenMax = max(g.lenMax-g.extraLength(), -1)
+	}
+	return lenMin, lenMax
+}
+
+// pickFittingWords fills the given slice with the indices of randomly picked
+// words. With length limits, the words are picked among the sequences that
+// fit within them, so that every one of these is as likely as any other.
+func (g *generator) pickFittingWords(wordIndices []int) error {
+	if !g.isLengthLimited() {
+		return g.pickWords(wordIndices)
+	}
+	return g.fittingWords.pick(g.rng, wordIndices)
+}
+
+// sanitizeLength ensures the length limits are valid, and that some of the
+// passphrases fit within them.
+func (g *generator) sanitizeLength() error {
+	if g.lenMin < 0 || g.lenMax < 0 || (g.lenMax > 0 && g.lenMin > g.lenMax) {
+		return ErrLengthInvalid
+	}
+	g.fittingWords = nil
+	if !g.isLengthLimited() {
+		return nil
+	}
+
+	lenMin, lenMax := g.wordsLenLimits()
+	if lenMax < 0 {
+		return ErrLengthUnreachable
+	}
+	g.fittingWords = newFittingWords(g.wordLengths, g.numWords, lenMin, lenMax)
+	if g.fittingWords.total.Sign() == 0 {
+		return ErrLengthUnreachable
+	}
+	return nil
+}
+
+// lengthGroup holds the indices of the words of the same length.
+type lengthGroup struct {
+	length  int
+	indices []int
+	// binomials holds the number of ways to pick n of the words, for every n
+	binomials []*big.Int
+}
+
+// fittingWords counts the sets of distinct words whose total length fits
+// within the limits, so that one of them can be picked at random directly.
+type fittingWords struct {
+	groups []lengthGroup
+	// counts[i][n][l] is the number of sets of n words from the groups i and
+	// later whose total length is l; lengths over lenCap are counted as
+	// lenCap if there is no maximum length, and not at all otherwise
+	counts [][][]*big.Int
+	lenCap int
+	lenMin int
+	capped bool
+	total  *big.Int
+}
+
+// newFittingWords groups the words by length, and counts the sets of numWords
+// of them that fit within the limits on the total length (none if 0).
+func newFittingWords(wordLengths []int, numWords int, lenMin int, lenMax int) *fittingWords {
+	f := &fittingWords{}
+	byLength := make(map[int][]int)
+	for idx, length := range wordLengths {
+		byLength[length] = append(byLength[length], idx)
+	}
+	lengths := make([]int, 0, len(byLength))
+	for length := range byLength {
+		lengths = append(lengths, length)
+	}
+	slices.Sort(lengths)
+	for _, length := range lengths {
+		group := lengthGroup{length: length, indices: byLength[length]}
+		for n := 0; n <= min(len(group.indices), numWords); n++ {
+			group.binomials = append(group.binomials, new(big.Int).Binomial(int64(len(group.indices)), int64(n)))
+		}
+		f.groups = append(f.groups, group)
+	}
+
+	// limits beyond the longest words change nothing, and would only make
+	// the counts bigger
+	lenLongest := numWords * lengths[len(lengths)-1]
+	if lenMax >= lenLongest {
+		lenMax = 0
+	}
+	f.lenMin, f.lenCap, f.capped = min(lenMin, lenLongest+1), max(min(lenMin, lenLongest+1), lenMax), lenMax == 0
+	f.countSets(numWords)
+
+	f.total = new(big.Int)
+	for length := f.lenMin; length <= f.lenCap; length++ {
+		if count := f.counts[0][numWords][length]; count != nil {
+			f.total.Add(f.total, count)
+		}
+	}
+	return f
+}
+
+// countSets fills in the counts of the sets of words, from the last group of
+// words to the first.
+func (f *fittingWords) countSets(numWords int) {
+	f.counts = make([][][]*big.Int, len(f.groups)+1)
+	for i := range f.counts {
+		f.counts[i] = make([][]*big.Int, numWords+1)
+		for n := range f.counts[i] {
+			f.counts[i][n] = make([]*big.Int, f.lenCap+1)
+		}
+	}
+	f.counts[len(f.groups)][0][0] = big.NewInt(1)
+
+	for i := len(f.groups) - 1; i >= 0; i-- {
+		group := f.groups[i]
+		for n, next := range f.counts[i+1] {
+			for length, count := range next {
+				if count == nil {
+					continue
+				}
+				for m := 0; m < len(group.binomials) && n+m <= numWords; m++ {
+					total, ok := f.capLength(length + m*group.length)
+					if !ok {
+						break
+					}
+					if f.counts[i][n+m][total] == nil {
+						f.counts[i][n+m][total] = new(big.Int)
+					}
+					f.counts[i][n+m][total].Add(f.counts[i][n+m][total], new(big.Int).Mul(group.binomials[m], count))
+				}
+			}
+		}
+	}
+}
+
+// capLength returns the given total length of words as counted, and false if
+// it is over the maximum length.
+func (f *fittingWords) capLength(length int) (int, bool) {
+	if length <= f.lenCap {
+		return length, true
+	}
+	return f.lenCap, f.capped
+}
+
+// pick fills the given slice with the indices of distinct words picked at
+// random among the sequences that fit within the length limits. It draws one
+// of the sets of words that fit, and then shuffles them, so that every
+// sequence is as likely as any other.
+func (f *fittingWords) pick(src rng.Source, wordIndices []int) error {
+	r, err := rand.Int(src, f.total)
+	if err != nil {
+		return err
+	}
+	numWords := len(wordIndices)
+	length := f.lenMin
+	for ; length < f.lenCap; length++ {
+		count := f.counts[0][numWords][length]
+		if count == nil {
+			continue
+		}
+		if r.Cmp(count) < 0 {
+			break
+		}
+		r.Sub(r, count)
+	}
+
+	picked := 0
+	for i := range f.groups {
+		numPicks, lengthRest := f.pickGroupCount(i, numWords-picked, length, r)
+		if err := f.groups[i].pickWords(src, wordIndices[picked:picked+numPicks]); err != nil {
+			return err
+		}
+		picked, length = picked+numPicks, lengthRest
+	}
+	// shuffle them without src.Shuffle, as its swap closure would move the
+	// indices to the heap
+	for i := numWords - 1; i > 0; i-- {
+		j, err := src.IntN(i + 1)
+		if err != nil {
+			return err
+		}
+		wordIndices[i], wordIndices[j] = wordIndices[j], wordIndices[i]
+	}
+	return nil
+}
+
+// pickGroupCount returns the number of words to pick from the group i, and
+// the total length left for the later groups, given r in [0, counts[i][n][l])
+// which is replaced by its rank among the sets of words of the later groups.
+func (f *fittingWords) pickGroupCount(i int, n int, length int, r *big.Int) (int, int) {
+	group, weight := f.groups[i], new(big.Int)
+	for m := 0; m < len(group.binomials) && m <= n; m++ {
+		lengthRest := length - m*group.length
+		lengthRestMax := lengthRest
+		if f.capped && length == f.lenCap {
+			// any total length of the later groups that gets capped as well
+			lengthRestMax = f.lenCap
+		}
+		for ; lengthRest <= lengthRestMax; lengthRest++ {
+			if lengthRest < 0 {
+				continue
+			}
+			count := f.counts[i+1][n-m][lengthRest]
+			if count == nil {
+				continue
+			}
+			if weight.Mul(group.binomials[m], count); r.Cmp(weight) < 0 {
+				r.Mod(r, count)
+				return m, lengthRest
+			}
+			r.Sub(r, weight)
+		}
+	}
+	// unreachable as r is lower than the sum of the weights
+	return 0, length
+}
+
+// pickWords fills the given slice with the indices of distinct words of the
+// group picked at random, using Robert Floyd's algorithm.
+func (lg *lengthGroup) pickWords(src rng.Source, wordIndices []int) error {
+	numWords := len(lg.indices)
+	for i, j := 0, numWords-len(wordIndices); j < numWords; i, j = i+1, j+1 {
+		k, err := src.IntN(j + 1)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(wordIndices[:i], lg.indices[k]) {
+			k = j
+		}
+		wordIndices[i] = lg.indices[k]
+	}
+	return nil
+}
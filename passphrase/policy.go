@@ -0,0 +1,253 @@
+package passphrase
+
+import (
+	"fmt"
+	"math/big"
+	"unicode"
+	"unicode/utf8"
+)
+
+// charClass is a class of characters a password policy may ask for.
+type charClass int
+
+const (
+	charClassUpper charClass = iota
+	charClassLower
+	charClassDigit
+	charClassSymbol
+	numCharClasses
+)
+
+var (
+	// minPolicyRatio is the minimum ratio of passphrases meeting the policy to
+	// all the passphrases that could be generated without it, so that
+	// GenerateTo can find one that does within its attempts: it fails to with
+	// a probability of at most (19/20)^1000, less than 1 in 2^73.
+	minPolicyRatio = big.NewRat(1, 20)
+
+	charClassNames = [numCharClasses]string{
+		charClassUpper:  "upper-case characters",
+		charClassLower:  "lower-case characters",
+		charClassDigit:  "digits",
+		charClassSymbol: "symbols",
+	}
+)
+
+// charClassCounts holds the number of characters of every class.
+type charClassCounts [numCharClasses]int
+
+// classOf returns the class of the character, if any; punctuation (like the
+// default separator "-") counts as a symbol.
+func classOf(r rune) (charClass, bool) {
+	switch {
+	case unicode.IsUpper(r):
+		return charClassUpper, true
+	case unicode.IsLower(r):
+		return charClassLower, true
+	case unicode.IsDigit(r):
+		return charClassDigit, true
+	case unicode.IsPunct(r) || unicode.IsSymbol(r):
+		return charClassSymbol, true
+	}
+	return 0, false
+}
+
+// countCharClasses returns the number of characters of every class in s.
+func countCharClasses(s []byte) charClassCounts {
+	var rsp charClassCounts
+	for len(s) > 0 {
+		r, size := utf8.DecodeRune(s)
+		if class, ok := classOf(r); ok {
+			rsp[class]++
+		}
+		s = s[size:]
+	}
+	return rsp
+}
+
+// hasPolicy returns true if the passphrases have to meet a password policy.
+func (g *generator) hasPolicy() bool {
+	return g.policy != charClassCounts{}
+}
+
+// meetsPolicy returns true if the passphrase has at least as many characters
+// of every class as the policy asks for.
+func (g *generator) meetsPolicy(passphrase []byte) bool {
+	return !g.hasPolicy() || g.meetsPolicyCounts(countCharClasses(passphrase))
+}
+
+// meetsPolicyCounts returns true if the counts of characters of every class
+// are at least as many as the policy asks for.
+func (g *generator) meetsPolicyCounts(counts charClassCounts) bool {
+	for class, minCount := range g.policy {
+		if counts[class] < minCount {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizePolicy ensures the policy is valid, and can be met by the
+// passphrases given the rest of the configuration.
+func (g *generator) sanitizePolicy() error {
+	if !g.hasPolicy() {
+		return nil
+	}
+
+	maxCounts := g.maxCharClassCounts()
+	total := 0
+	for class, minCount := range g.policy {
+		if minCount < 0 {
+			return ErrPolicyInvalid
+		}
+		if minCount > maxCounts[class] {
+			return fmt.Errorf("%w: need at least %d %s, found at most %d",
+				ErrPolicyUnsatisfiable, minCount, charClassNames[class], maxCounts[class])
+		}
+		total += minCount
+	}
+	if g.lenMax > 0 && total > g.lenMax {
+		return fmt.Errorf("%w: need at least %d characters, found at most %d",
+			ErrPolicyUnsatisfiable, total, g.lenMax)
+	}
+
+	// enough of the passphrases have to meet the policy for GenerateTo to
+	// find one within its attempts
+	ratio := new(big.Rat).SetFrac(g.keyspace(true), g.keyspace(false))
+	if ratio.Cmp(minPolicyRatio) < 0 {
+		percent, _ := ratio.Float64()
+		return fmt.Errorf("%w: met by only %.2g%% of the passphrases, need at least %s%%",
+			ErrPolicyUnsatisfiable, percent*100, new(big.Rat).Mul(minPolicyRatio, big.NewRat(100, 1)).RatString())
+	}
+	return nil
+}
+
+// maxCharClassCounts returns (an upper bound of) the most characters of every
+// class a passphrase can have: in the words in any case they may be
+// generated in, the separators, and the numbers and symbols injected.
+func (g *generator) maxCharClassCounts() charClassCounts {
+	var rsp charClassCounts
+	var words [][]string
+	switch g.casing {
+	case CasingNone:
+		words = [][]string{g.dictionary}
+	case CasingLower:
+		words = [][]string{g.casedWords[wordCaseLower]}
+	case CasingTitle:
+		words = [][]string{g.casedWords[wordCaseTitle]}
+	case CasingUpper:
+		words = [][]string{g.casedWords[wordCaseUpper]}
+	default:
+		words = g.casedWords[:]
+	}
+	var wordMax charClassCounts
+	for _, variants := range words {
+		for _, word := range variants {
+			for class, count := range countCharClasses([]byte(word)) {
+				wordMax[class] = max(wordMax[class], count)
+			}
+		}
+	}
+
+	separatorMax := countCharClasses([]byte(g.separator))
+	if g.randomSeparators {
+		separatorMax = runeClasses(g.separators)
+	}
+	symbolMax := runeClasses(g.symbols.runes)
+	numGaps := g.numWords + g.numbers.numTokens() + g.symbols.numTokens() - 1
+	for class := range rsp {
+		rsp[class] = wordMax[class]*g.numWords + separatorMax[class]*numGaps + symbolMax[class]*g.symbols.count
+	}
+	rsp[charClassDigit] += g.numbers.count * g.numbers.width
+	return rsp
+}
+
+// runeClasses returns 1 for every class the runes have a character of.
+func runeClasses(runes []rune) charClassCounts {
+	var rsp charClassCounts
+	for _, r := range runes {
+		if class, ok := classOf(r); ok {
+			rsp[class] = 1
+		}
+	}
+	return rsp
+}
+
+// classKeyspaces holds the number of distinct ways to get every (capped)
+// count of characters of every class.
+type classKeyspaces map[charClassCounts]*big.Int
+
+// addKeyspace adds the ways to get the counts to the keyspaces.
+func addKeyspace(keyspaces classKeyspaces, counts charClassCounts, ways *big.Int) {
+	if keyspaces[counts] == nil {
+		keyspaces[counts] = new(big.Int)
+	}
+	keyspaces[counts].Add(keyspaces[counts], ways)
+}
+
+// scaleCounts returns the counts multiplied by n.
+func scaleCounts(counts charClassCounts, n int) charClassCounts {
+	for class := range counts {
+		counts[class] *= n
+	}
+	return counts
+}
+
+// capped returns the counts capped at the minimums of the policy, as more
+// characters of a class than the policy asks for make no difference to it.
+func (g *generator) capped(counts charClassCounts) charClassCounts {
+	for class := range counts {
+		counts[class] = min(counts[class], g.policy[class])
+	}
+	return counts
+}
+
+// addCapped returns the sum of the counts, capped at the minimums of the
+// policy.
+func (g *generator) addCapped(a, b charClassCounts) charClassCounts {
+	for class := range a {
+		a[class] += b[class]
+	}
+	return g.capped(a)
+}
+
+// combineKeyspaces returns the number of distinct ways to get every (capped)
+// count of characters of every class by combining the ways in a and b.
+func (g *generator) combineKeyspaces(a, b classKeyspaces) classKeyspaces {
+	rsp := make(classKeyspaces, len(a))
+	for countsA, waysA := range a {
+		for countsB, waysB := range b {
+			addKeyspace(rsp, g.addCapped(countsA, countsB), new(big.Int).Mul(waysA, waysB))
+		}
+	}
+	return rsp
+}
+
+// runesKeyspaces returns the number of distinct ways to pick n runes from the
+// given ones (or one of them n times if same), by the (capped) number of
+// characters of every class in them.
+func (g *generator) runesKeyspaces(runes []rune, n int, same bool) classKeyspaces {
+	rsp := classKeyspaces{{}: big.NewInt(1)}
+	if n == 0 {
+		return rsp
+	}
+
+	picks := make(classKeyspaces)
+	for _, r := range runes {
+		var counts charClassCounts
+		if class, ok := classOf(r); ok {
+			counts[class] = 1
+		}
+		if same {
+			counts = scaleCounts(counts, n)
+		}
+		addKeyspace(picks, g.capped(counts), big.NewInt(1))
+	}
+	if same {
+		return picks
+	}
+	for idx := 0; idx < n; idx++ {
+		rsp = g.combineKeyspaces(rsp, picks)
+	}
+	return rsp
+}
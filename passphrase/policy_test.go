@@ -0,0 +1,142 @@
+package passphrase
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/rng"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Generate_WithPolicy(t *testing.T) {
+	for name, rules := range map[string][]Rule{
+		"classic": {
+			WithMaxLength(24),
+			WithMinDigits(1),
+			WithMinLowerCase(1),
+			WithMinSymbols(1),
+			WithMinUpperCase(1),
+		},
+		"met only by some": {
+			WithCapitalizedWords(false),
+			WithCasing(CasingRandom),
+			WithMinDigits(2),
+			WithMinSymbols(3),
+			WithMinUpperCase(2),
+			WithNumNumbers(2),
+			WithNumWords(4),
+			WithRandomSeparators(charset.Numbers + charset.Symbols),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewGenerator(rules...)
+			assert.Nil(t, err)
+			gen := g.(*generator)
+
+			for idx := 0; idx < 1000; idx++ {
+				passphrase, err := g.Generate()
+				assert.NoError(t, err)
+
+				var numUpper, numLower, numDigits, numSymbols int
+				for _, r := range passphrase {
+					switch {
+					case unicode.IsUpper(r):
+						numUpper++
+					case unicode.IsLower(r):
+						numLower++
+					case unicode.IsDigit(r):
+						numDigits++
+					default:
+						numSymbols++
+					}
+				}
+				assert.GreaterOrEqual(t, numUpper, gen.policy[charClassUpper], passphrase)
+				assert.GreaterOrEqual(t, numLower, gen.policy[charClassLower], passphrase)
+				assert.GreaterOrEqual(t, numDigits, gen.policy[charClassDigit], passphrase)
+				assert.GreaterOrEqual(t, numSymbols, gen.policy[charClassSymbol], passphrase)
+				if gen.lenMax > 0 {
+					assert.LessOrEqual(t, utf8.RuneCountInString(passphrase), gen.lenMax, passphrase)
+				}
+			}
+		})
+	}
+
+	t.Run("retry budget exhausted", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCasing(CasingRandom),
+			WithDictionary(testDictionary(300, false)),
+			WithMinUpperCase(12),
+			WithNumWords(4),
+			WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+		)
+		assert.Nil(t, err)
+
+		maxGenerateAttempts = 1
+		defer func() { maxGenerateAttempts = 1000 }()
+		passphrase, err := g.Generate()
+		assert.Empty(t, passphrase)
+		assert.True(t, errors.Is(err, ErrPolicyNotMet))
+		assert.EqualError(t, err, "failed to generate a passphrase meeting the policy in 1 attempts")
+	})
+}
+
+func TestNewGenerator_WithPolicy_Invalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rules []Rule
+		err   string
+	}{
+		"negative": {
+			rules: []Rule{WithMinDigits(-1)},
+			err:   "policy rule invalid",
+		},
+		"no upper-case": {
+			rules: []Rule{WithCasing(CasingLower), WithMinUpperCase(1)},
+			err:   "passphrases cannot meet the policy: need at least 1 upper-case characters, found at most 0",
+		},
+		"no lower-case": {
+			rules: []Rule{WithCasing(CasingUpper), WithMinLowerCase(1)},
+			err:   "passphrases cannot meet the policy: need at least 1 lower-case characters, found at most 0",
+		},
+		"too few digits": {
+			rules: []Rule{WithDictionary(testDictionary(300, false)), WithMinDigits(2)},
+			err:   "passphrases cannot meet the policy: need at least 2 digits, found at most 1",
+		},
+		"no symbols": {
+			rules: []Rule{WithMinSymbols(1), WithSeparator(" ")},
+			err:   "passphrases cannot meet the policy: need at least 1 symbols, found at most 0",
+		},
+		"rarely met": {
+			rules: []Rule{
+				WithCapitalizedWords(false),
+				WithDictionary(append([]string{"Waaa"}, testDictionary(5000, false)[1:]...)),
+				WithMinUpperCase(1),
+			},
+			err: "passphrases cannot meet the policy: met by only 0.06% of the passphrases, need at least 5%",
+		},
+		"longer than the max length": {
+			rules: []Rule{
+				WithCasing(CasingAlternating),
+				WithMaxLength(20),
+				WithMinDigits(1),
+				WithMinLowerCase(10),
+				WithMinSymbols(2),
+				WithMinUpperCase(8),
+			},
+			err: "passphrases cannot meet the policy: need at least 21 characters, found at most 20",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewGenerator(tc.rules...)
+			assert.Nil(t, g)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	g, err := NewDicewareGenerator(WithMinLowerCase(1))
+	assert.Nil(t, g)
+	assert.Equal(t, ErrDicewareRuleUnsupported, err)
+}
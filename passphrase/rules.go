@@ -1,6 +1,10 @@
 package passphrase
 
-import "github.com/jedib0t/go-passwords/passphrase/dictionaries"
+import (
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/passphrase/dictionaries"
+	"github.com/jedib0t/go-passwords/rng"
+)
 
 // Rule controls how the Generator/Sequencer generates passwords.
 type Rule func(g *generator)
@@ -8,21 +12,38 @@ type Rule func(g *generator)
 var (
 	basicRules = []Rule{
 		WithCapitalizedWords(true),
+		WithCasing(CasingNone),
 		WithDictionary(dictionaries.English()),
+		WithNumDigits(1),
 		WithNumWords(3),
 		WithNumber(true),
+		WithNumberPlacement(PlacementSuffix),
+		WithRandomSource(rng.Default()),
 		WithSeparator("-"),
+		WithSymbolPlacement(PlacementSuffix),
 		WithWordLength(4, 7),
 	}
 )
 
-// WithCapitalizedWords ensures the words are Capitalized.
+// WithCapitalizedWords ensures the words are Capitalized, by converting the
+// first character of every word to title case.
 func WithCapitalizedWords(enabled bool) Rule {
 	return func(g *generator) {
 		g.capitalize = enabled
 	}
 }
 
+// WithCasing sets the case of the words in the passphrases, applied every time
+// a passphrase is generated; the words are converted to lower-case before
+// being cased, and WithCapitalizedWords is ignored unless this is CasingNone.
+// Casing the words at random (CasingRandomWordUpper, CasingRandom) adds to the
+// entropy of the passphrases.
+func WithCasing(casing Casing) Rule {
+	return func(g *generator) {
+		g.casing = casing
+	}
+}
+
 // WithDictionary sets the dictionary of words to use for the passphrase.
 func WithDictionary(words []string) Rule {
 	return func(g *generator) {
@@ -30,10 +51,135 @@ func WithDictionary(words []string) Rule {
 	}
 }
 
-// WithNumber injects a random number after one of the words in the passphrase.
+// WithExcludedSubstrings prevents the given substrings (like user names) from
+// appearing in the passphrases. Words containing any of them are removed from
+// the dictionary, and passphrases containing any of them across words are
+// discarded and generated again, up to a limited number of attempts before
+// GenerateTo gives up with an *ExcludedSubstringsError.
+func WithExcludedSubstrings(substrings []string) Rule {
+	// ignore empty substrings as they would match every passphrase
+	var nonEmpty []string
+	for _, substring := range substrings {
+		if substring != "" {
+			nonEmpty = append(nonEmpty, substring)
+		}
+	}
+
+	return func(g *generator) {
+		g.excludedSubstrings = nonEmpty
+	}
+}
+
+// WithExcludedSubstringsIgnoreCase controls whether the substrings set using
+// WithExcludedSubstrings are matched regardless of their case.
+func WithExcludedSubstringsIgnoreCase(ignoreCase bool) Rule {
+	return func(g *generator) {
+		g.excludeIgnoreCase = ignoreCase
+	}
+}
+
+// WithMaxLength sets the maximum length of the passphrases, in user-perceived
+// characters (grapheme clusters) including the separators, numbers and
+// symbols; 0 means no limit. The words are picked among the ones that fit, so
+// that every passphrase within the limits is as likely as any other, and
+// NewGenerator returns ErrLengthUnreachable if none of them fit.
+func WithMaxLength(n int) Rule {
+	return func(g *generator) {
+		g.lenMax = n
+	}
+}
+
+// WithMinDigits sets the minimum number of digits in the passphrases, as a
+// password policy would. Passphrases not meeting the policy are discarded and
+// generated again like the ones with excluded substrings, and NewGenerator
+// returns ErrPolicyUnsatisfiable if none (or less than 1 in 20) of them can
+// meet it, so that GenerateTo only returns ErrPolicyNotMet with a negligible
+// probability (less than 1 in 2^73). The entropy only accounts for the
+// passphrases meeting the policy.
+func WithMinDigits(n int) Rule {
+	return func(g *generator) {
+		g.policy[charClassDigit] = n
+	}
+}
+
+// WithMinEntropy ensures the Generator is configured to generate passphrases
+// with at least the given bits of entropy; NewGenerator returns
+// ErrEntropyTooLow otherwise.
+func WithMinEntropy(bits float64) Rule {
+	return func(g *generator) {
+		g.minEntropy = bits
+	}
+}
+
+// WithMinLowerCase sets the minimum number of lower-case characters in the
+// passphrases, as a password policy would; see WithMinDigits.
+func WithMinLowerCase(n int) Rule {
+	return func(g *generator) {
+		g.policy[charClassLower] = n
+	}
+}
+
+// WithMinLength sets the minimum length of the passphrases, in user-perceived
+// characters (grapheme clusters) including the separators, numbers and
+// symbols; 0 means no limit. The words are picked among the ones that fit,
+// just like with WithMaxLength.
+func WithMinLength(n int) Rule {
+	return func(g *generator) {
+		g.lenMin = n
+	}
+}
+
+// WithMinSymbols sets the minimum number of symbols (including punctuation
+// like the separator "-") in the passphrases, as a password policy would; see
+// WithMinDigits.
+func WithMinSymbols(n int) Rule {
+	return func(g *generator) {
+		g.policy[charClassSymbol] = n
+	}
+}
+
+// WithMinUpperCase sets the minimum number of upper-case characters in the
+// passphrases, as a password policy would; see WithMinDigits.
+func WithMinUpperCase(n int) Rule {
+	return func(g *generator) {
+		g.policy[charClassUpper] = n
+	}
+}
+
+// WithNumDigits sets the number of digits in every number injected into the
+// passphrase (ex.: 2 for numbers from "00" to "99").
+func WithNumDigits(n int) Rule {
+	return func(g *generator) {
+		g.numbers.runes = digits
+		g.numbers.width = n
+	}
+}
+
+// WithNumNumbers sets the number of random numbers injected into the
+// passphrase.
+func WithNumNumbers(n int) Rule {
+	return func(g *generator) {
+		g.numbers.count = n
+	}
+}
+
+// WithNumber injects a random number into the passphrase, after one of the
+// words by default; it is the same as WithNumNumbers(1), or WithNumNumbers(0)
+// when disabled.
 func WithNumber(enabled bool) Rule {
 	return func(g *generator) {
-		g.withNumber = enabled
+		g.numbers.count = 0
+		if enabled {
+			g.numbers.count = 1
+		}
+	}
+}
+
+// WithNumberPlacement sets where the numbers are injected into the
+// passphrase.
+func WithNumberPlacement(p Placement) Rule {
+	return func(g *generator) {
+		g.numbers.placement = p
 	}
 }
 
@@ -44,14 +190,68 @@ func WithNumWords(n int) Rule {
 	}
 }
 
-// WithSeparator sets up the delimiter to separate words.
+// WithRandomSeparators separates the words with separators picked at random
+// from the charset, a different one for every gap unless
+// WithSameRandomSeparator is enabled; it overrides WithSeparator.
+func WithRandomSeparators(c charset.Charset) Rule {
+	return func(g *generator) {
+		g.randomSeparators = true
+		g.separators = []rune(c.WithoutDuplicates())
+	}
+}
+
+// WithRandomSource sets the source of randomness used to generate passphrases.
+// A nil Source resets it to the default buffered crypto/rand Source.
+func WithRandomSource(src rng.Source) Rule {
+	if src == nil {
+		src = rng.Default()
+	}
+
+	return func(g *generator) {
+		g.rng = src
+	}
+}
+
+// WithSameRandomSeparator ensures the separator picked at random (using
+// WithRandomSeparators) is the same for every gap in the passphrase.
+func WithSameRandomSeparator(enabled bool) Rule {
+	return func(g *generator) {
+		g.sameSeparator = enabled
+	}
+}
+
+// WithSeparator sets up the delimiter to separate words; it overrides
+// WithRandomSeparators.
 func WithSeparator(s string) Rule {
 	return func(g *generator) {
+		g.randomSeparators = false
 		g.separator = s
+		g.separators = nil
+	}
+}
+
+// WithSymbolPlacement sets where the symbols are injected into the
+// passphrase.
+func WithSymbolPlacement(p Placement) Rule {
+	return func(g *generator) {
+		g.symbols.placement = p
+	}
+}
+
+// WithSymbols injects the given number of random symbols from the charset
+// into the passphrase, after as many words by default.
+func WithSymbols(c charset.Charset, count int) Rule {
+	return func(g *generator) {
+		g.symbols.count = count
+		g.symbols.runes = []rune(c.WithoutDuplicates())
+		g.symbols.width = 1
 	}
 }
 
-// WithWordLength sets the minimum and maximum length of the words in the passphrase.
+// WithWordLength sets the minimum and maximum length of the words in the
+// passphrase, in user-perceived characters (grapheme clusters) and not bytes;
+// for ex., "café" is 4 characters long even when its "é" is an "e" followed
+// by a combining accent.
 func WithWordLength(min, max int) Rule {
 	return func(g *generator) {
 		g.wordLenMin = min
@@ -0,0 +1,52 @@
+package passphrase
+
+import (
+	"math/big"
+	"unicode/utf8"
+)
+
+// maxSeparatorBytes returns the size in bytes of the longest separator.
+func (g *generator) maxSeparatorBytes() int {
+	if !g.randomSeparators {
+		return len(g.separator)
+	}
+	rsp := 0
+	for _, r := range g.separators {
+		rsp = max(rsp, utf8.RuneLen(r))
+	}
+	return rsp
+}
+
+// pickSeparator returns the index of a random separator, or -1 if the
+// separator is not random.
+func (g *generator) pickSeparator() (int, error) {
+	if !g.randomSeparators {
+		return -1, nil
+	}
+	return g.randomIndex(len(g.separators))
+}
+
+// separatorsKeyspaces returns the number of distinct ways the gaps between the
+// given number of tokens can be separated, by the (capped) number of
+// characters of every class in the separators.
+func (g *generator) separatorsKeyspaces(numTokens int) classKeyspaces {
+	if !g.randomSeparators {
+		counts := scaleCounts(countCharClasses([]byte(g.separator)), numTokens-1)
+		return classKeyspaces{g.capped(counts): big.NewInt(1)}
+	}
+	return g.runesKeyspaces(g.separators, numTokens-1, g.sameSeparator)
+}
+
+// writeSeparatorToBuf writes the separator at the given index (picked using
+// pickSeparator) to the buffer.
+func (g *generator) writeSeparatorToBuf(buf []byte, offset *int, separatorIdx int) error {
+	if separatorIdx < 0 {
+		return writeStringToBuf(buf, offset, g.separator)
+	}
+	r := g.separators[separatorIdx]
+	if *offset+utf8.RuneLen(r) > len(buf) {
+		return ErrBufferTooSmall
+	}
+	*offset += utf8.EncodeRune(buf[*offset:], r)
+	return nil
+}
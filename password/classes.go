@@ -0,0 +1,153 @@
+package password
+
+import (
+	"fmt"
+	"math"
+	"unicode"
+
+	"github.com/jedib0t/go-passwords/charset"
+)
+
+// indices of the built-in classes returned by newCharClasses; the classes
+// defined using WithCharClass follow these
+const (
+	classLowerCase = iota
+	classUpperCase
+	classNumbers
+	classSymbols
+	classOthers
+)
+
+// charClass is a set of distinct characters from the charset that must appear
+// between min and max times in the password.
+type charClass struct {
+	runes []rune
+	min   int
+	max   int
+	// limit is the max as set by the rules, before capping it to what the
+	// password can hold; Validate applies it (math.MaxInt if not set)
+	limit      int
+	errTooFew  error
+	errTooMany error
+}
+
+// customCharClass is a named class of characters defined using WithCharClass.
+type customCharClass struct {
+	name    string
+	charset charset.Charset
+	min     int
+	max     int
+}
+
+// newCharClasses splits the (de-duplicated) charset into disjoint classes
+// along with the number of times each class can appear in the password. The
+// characters of a custom class count only towards that class, and not towards
+// the built-in class they would belong to otherwise.
+func (g *generator) newCharClasses() []charClass {
+	var lowerCase, upperCase, numbers, symbols, others []rune
+	uniqueChars := []rune(charset.Charset(g.charset).WithoutDuplicates())
+	for _, r := range uniqueChars {
+		switch {
+		case g.customCharClassIndex(r) >= 0:
+			continue
+		case g.symbols.Contains(r):
+			symbols = append(symbols, r)
+		case unicode.IsLower(r):
+			lowerCase = append(lowerCase, r)
+		case unicode.IsUpper(r):
+			upperCase = append(upperCase, r)
+		case unicode.IsDigit(r):
+			numbers = append(numbers, r)
+		default:
+			others = append(others, r)
+		}
+	}
+
+	symbolsLimit := math.MaxInt
+	if g.numSymbolsSet {
+		symbolsLimit = g.maxSymbols
+	}
+	classes := []charClass{
+		{runes: lowerCase, min: g.minLowerCase, max: min(g.maxLowerCase, g.numChars), limit: g.maxLowerCase, errTooFew: ErrTooFewLowerCase, errTooMany: ErrTooManyLowerCase},
+		{runes: upperCase, min: g.minUpperCase, max: min(g.maxUpperCase, g.numChars), limit: g.maxUpperCase, errTooFew: ErrTooFewUpperCase, errTooMany: ErrTooManyUpperCase},
+		{runes: numbers, min: g.minNumbers, max: min(g.maxNumbers, g.numChars), limit: g.maxNumbers, errTooFew: ErrTooFewNumbers, errTooMany: ErrTooManyNumbers},
+		{runes: symbols, min: g.minSymbols, max: g.maxSymbols, limit: symbolsLimit, errTooFew: ErrTooFewSymbols, errTooMany: ErrTooManySymbols},
+		{runes: others, min: 0, max: g.numChars, limit: math.MaxInt},
+	}
+	for _, cc := range g.customCharClasses {
+		classes = append(classes, charClass{
+			runes:      filterRunes(uniqueChars, cc.charset.Contains),
+			min:        cc.min,
+			max:        min(cc.max, g.numChars),
+			limit:      cc.max,
+			errTooFew:  fmt.Errorf("%w %q", ErrTooFewInCharClass, cc.name),
+			errTooMany: fmt.Errorf("%w %q", ErrTooManyInCharClass, cc.name),
+		})
+	}
+	if g.uniqueChars {
+		// a class cannot appear more times than it has characters
+		for idx := range classes {
+			classes[idx].max = min(classes[idx].max, len(classes[idx].runes))
+		}
+	}
+	return classes
+}
+
+// customCharClassIndex returns the index of the first custom class containing
+// the character, or -1 if there is no such class.
+func (g *generator) customCharClassIndex(r rune) int {
+	for idx, cc := range g.customCharClasses {
+		if cc.charset.Contains(r) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// sanitizeCustomCharClasses ensures the custom classes do not share any
+// characters, and that the characters needed by them are in the charset.
+func (g *generator) sanitizeCustomCharClasses() error {
+	for idx, cc := range g.customCharClasses {
+		for _, r := range cc.charset {
+			if idx2 := g.customCharClassIndex(r); idx2 != idx {
+				return fmt.Errorf("%w: %q and %q share %q",
+					ErrCharClassesOverlap, g.customCharClasses[idx2].name, cc.name, r)
+			}
+		}
+		if cc.min > 0 && len(g.charClasses[classOthers+1+idx].runes) == 0 {
+			return fmt.Errorf("%w: %q", ErrNoCharClassInCharset, cc.name)
+		}
+	}
+	return nil
+}
+
+// charClassIndex returns the index of the class the character belongs to, or
+// -1 if the character is not in the charset.
+func (g *generator) charClassIndex(r rune) int {
+	for idx, class := range g.charClasses {
+		for _, r2 := range class.runes {
+			if r == r2 {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// newRemainingRunes returns the characters to fill the rest of the password
+// with if none of the classes (other than the symbols) can reach its maximum
+// count, so that GenerateTo does not have to gather them every time; nil
+// otherwise.
+func (g *generator) newRemainingRunes() []rune {
+	var rsp []rune
+	for cIdx, class := range g.charClasses {
+		if cIdx == classSymbols {
+			continue
+		}
+		if class.max < g.numChars {
+			return nil
+		}
+		rsp = append(rsp, class.runes...)
+	}
+	return rsp
+}
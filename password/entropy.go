@@ -0,0 +1,85 @@
+package password
+
+import (
+	"math/big"
+
+	"github.com/jedib0t/go-passwords/entropy"
+)
+
+// Entropy returns the exact number of distinct passwords the Generator can
+// generate, and the bits of entropy it translates to. It is an upper bound
+// when runs of repeated or sequential characters are restricted, or when
+// unique characters are combined with position rules. Excluded substrings are
+// accounted for exactly, or with a lower bound when characters are unique or
+// the class limits are too many to follow.
+func (g *generator) Entropy() entropy.Stats {
+	keyspace := g.keyspace()
+	if len(g.excluded) > 0 {
+		keyspace = g.excludedKeyspace(keyspace)
+	}
+	return entropy.New(keyspace)
+}
+
+// keyspace returns the number of distinct passwords the Generator can
+// generate.
+func (g *generator) keyspace() *big.Int {
+	if len(g.positions) > 0 {
+		return g.positionsKeyspace()
+	}
+	return keyspace(g.numChars, g.charClasses, g.uniqueChars)
+}
+
+// keyspace returns the number of distinct strings of the given length that can
+// be built using the given disjoint classes while honoring the min/max count of
+// each class, and optionally using each character at most once.
+func keyspace(length int, classes []charClass, unique bool) *big.Int {
+	// ways[n] holds the number of ways to fill n positions using the classes
+	// processed so far
+	ways := make([]*big.Int, length+1)
+	for idx := range ways {
+		ways[idx] = new(big.Int)
+	}
+	ways[0].SetInt64(1)
+
+	term, coef, power, factor := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	for _, class := range classes {
+		next := make([]*big.Int, length+1)
+		for idx := range next {
+			next[idx] = new(big.Int)
+		}
+		numRunes := int64(len(class.runes))
+		power.SetInt64(1)
+		for k := 0; k <= class.max && k <= length; k++ {
+			// the number of ways to pick k characters of this class, built up
+			// from the one for k-1
+			if k > 0 {
+				if unique {
+					power.Mul(power, factor.SetInt64(numRunes-int64(k)+1))
+				} else {
+					power.Mul(power, factor.SetInt64(numRunes))
+				}
+			}
+			if power.Sign() == 0 {
+				break
+			}
+			if k < class.min {
+				continue
+			}
+
+			// place k characters of this class in any of the n+k positions;
+			// coef holds C(n+k, k) times the power, built up from n = 0
+			coef.Set(power)
+			for n := 0; n+k <= length; n++ {
+				if n > 0 {
+					coef.Mul(coef, factor.SetInt64(int64(n+k)))
+					coef.Quo(coef, factor.SetInt64(int64(n)))
+				}
+				if ways[n].Sign() != 0 {
+					next[n+k].Add(next[n+k], term.Mul(coef, ways[n]))
+				}
+			}
+		}
+		ways = next
+	}
+	return ways[length]
+}
@@ -0,0 +1,240 @@
+package password
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"unicode"
+
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Entropy(t *testing.T) {
+	t.Run("no constraints", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithLength(12),
+		)
+		assert.Nil(t, err)
+
+		stats := g.Entropy()
+		expected := new(big.Int).Exp(big.NewInt(62), big.NewInt(12), nil)
+		assert.Equal(t, expected.String(), stats.Keyspace.String())
+		assert.InDelta(t, 12*math.Log2(62), stats.Bits, 0.000001)
+	})
+
+	t.Run("long password", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AllChars),
+			WithLength(256),
+			WithNumSymbols(0, 256),
+		)
+		assert.Nil(t, err)
+
+		numChars := int64(len(charset.AllChars.WithoutDuplicates()))
+		expected := new(big.Int).Exp(big.NewInt(numChars), big.NewInt(256), nil)
+		assert.Equal(t, expected.String(), g.Entropy().Keyspace.String())
+	})
+
+	t.Run("duplicates in charset", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.Numbers+charset.Numbers),
+			WithLength(4),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, "10000", g.Entropy().Keyspace.String())
+	})
+
+	t.Run("symbols not requested", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.Numbers+charset.Symbols),
+			WithLength(4),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, "10000", g.Entropy().Keyspace.String())
+	})
+
+	t.Run("matches brute-force", func(t *testing.T) {
+		cs := charset.Charset("abcDE12!@")
+		for _, rules := range [][]Rule{
+			{WithLength(4)},
+			{WithLength(4), WithMinLowerCase(1)},
+			{WithLength(4), WithMinLowerCase(2), WithMinUpperCase(1)},
+			{WithLength(4), WithNumSymbols(1, 1)},
+			{WithLength(4), WithNumSymbols(0, 2), WithMinUpperCase(1)},
+			{WithLength(5), WithMinLowerCase(1), WithMinUpperCase(1), WithNumSymbols(1, 3)},
+			{WithLength(4), WithMinNumbers(2)},
+			{WithLength(5), WithMinUpperCase(1), WithNumNumbers(1, 2), WithNumSymbols(0, 1)},
+			{WithLength(5), WithNumLowerCase(1, 2), WithNumUpperCase(0, 1), WithNumSymbols(1, 2)},
+		} {
+			g, err := NewGenerator(append([]Rule{WithCharset(cs)}, rules...)...)
+			assert.Nil(t, err)
+			gen := g.(*generator)
+
+			expected := bruteForceKeyspace(cs, gen.numChars, func(pw []rune) bool {
+				numNumbers := len(filterRunes(pw, unicode.IsDigit))
+				numSymbols := len(filterRunes(pw, charset.Symbols.Contains))
+				numLowerCase := len(filterRunes(pw, unicode.IsLower))
+				numUpperCase := len(filterRunes(pw, unicode.IsUpper))
+				return numLowerCase >= gen.minLowerCase && numLowerCase <= gen.maxLowerCase &&
+					numUpperCase >= gen.minUpperCase && numUpperCase <= gen.maxUpperCase &&
+					numNumbers >= gen.minNumbers && numNumbers <= gen.maxNumbers &&
+					numSymbols >= gen.minSymbols && numSymbols <= gen.maxSymbols
+			})
+			assert.Equal(t, expected, g.Entropy().Keyspace.Int64())
+		}
+	})
+}
+
+func TestGenerator_Entropy_WithCharClass(t *testing.T) {
+	cs := charset.Charset("abC1-_")
+	g, err := NewGenerator(
+		WithCharset(cs),
+		WithLength(4),
+		WithMinLowerCase(1),
+		WithCharClass("separators", "-_", 1, 2),
+	)
+	assert.Nil(t, err)
+
+	expected := bruteForceKeyspace(cs, 4, func(pw []rune) bool {
+		numSeparators := len(filterRunes(pw, charset.Charset("-_").Contains))
+		return len(filterRunes(pw, unicode.IsLower)) >= 1 &&
+			numSeparators >= 1 && numSeparators <= 2
+	})
+	assert.Equal(t, expected, g.Entropy().Keyspace.Int64())
+}
+
+func TestGenerator_Entropy_WithPositions(t *testing.T) {
+	cs := charset.Charset("abC12!@")
+	for _, rules := range [][]Rule{
+		{WithFirstCharFrom("aC")},
+		{WithLastCharFrom(charset.Numbers), WithNumNumbers(1, 2)},
+		{WithFirstCharFrom("ab!"), WithForbiddenAt(1, "ab"), WithForbiddenAt(-1, "!@"), WithNumSymbols(1, 2)},
+		{WithFirstCharFrom("C"), WithForbiddenAt(0, "C"), WithMinUpperCase(1)},
+	} {
+		g, err := NewGenerator(append([]Rule{WithCharset(cs), WithLength(4)}, rules...)...)
+		if err != nil {
+			assert.Equal(t, ErrPositionsNotSatisfiable, err)
+			continue
+		}
+		gen := g.(*generator)
+
+		expected := bruteForceKeyspace(cs, gen.numChars, func(pw []rune) bool {
+			return isGeneratable(gen, pw)
+		})
+		assert.Equal(t, expected, g.Entropy().Keyspace.Int64())
+	}
+}
+
+func TestGenerator_Entropy_WithUniqueCharacters(t *testing.T) {
+	cs := charset.Charset("abcDE12!@")
+	for _, rules := range [][]Rule{
+		{WithLength(4)},
+		{WithLength(5), WithMinLowerCase(2), WithNumSymbols(1, 2)},
+		{WithLength(4), WithNumUpperCase(1, 1), WithNumNumbers(0, 1)},
+	} {
+		g, err := NewGenerator(append([]Rule{WithCharset(cs), WithUniqueCharacters(true)}, rules...)...)
+		assert.Nil(t, err)
+		gen := g.(*generator)
+
+		expected := bruteForceKeyspace(cs, gen.numChars, func(pw []rune) bool {
+			return isGeneratable(gen, pw)
+		})
+		assert.Equal(t, expected, g.Entropy().Keyspace.Int64())
+	}
+}
+
+func TestGenerator_Entropy_WithExcludedSubstrings(t *testing.T) {
+	cs := charset.Charset("abcdefgH12")
+	for _, rules := range [][]Rule{
+		{WithExcludedSubstrings([]string{"abc", "HG"}), WithExcludedSubstringsIgnoreCase(true)},
+		{WithExcludedSubstrings([]string{"aa", "a1", "1a"}), WithNumNumbers(1, 2), WithMinLowerCase(2)},
+		{WithExcludedSubstrings([]string{"bab", "ab"}), WithFirstCharFrom("ab"), WithForbiddenAt(-1, "12")},
+		{WithExcludedSubstrings([]string{"xyz", "abcdefgh"})},
+	} {
+		g, err := NewGenerator(append([]Rule{WithCharset(cs), WithLength(5)}, rules...)...)
+		assert.Nil(t, err)
+		gen := g.(*generator)
+
+		expected := bruteForceKeyspace(cs, gen.numChars, func(pw []rune) bool {
+			return isGeneratable(gen, pw)
+		})
+		assert.Equal(t, expected, g.Entropy().Keyspace.Int64())
+	}
+
+	t.Run("overlapping substrings", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset("abc"),
+			WithLength(12),
+			WithExcludedSubstrings([]string{"aa", "bb", "cc"}),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, "6144", g.Entropy().Keyspace.String())
+	})
+
+	t.Run("unique characters", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(cs),
+			WithLength(4),
+			WithUniqueCharacters(true),
+			WithExcludedSubstrings([]string{"ab", "H1"}),
+		)
+		assert.Nil(t, err)
+		gen := g.(*generator)
+
+		expected := bruteForceKeyspace(cs, gen.numChars, func(pw []rune) bool {
+			return isGeneratable(gen, pw)
+		})
+		actual := g.Entropy().Keyspace.Int64()
+		assert.LessOrEqual(t, actual, expected)
+		assert.Greater(t, actual, expected*9/10)
+	})
+}
+
+// isGeneratable returns true if the Generator could generate the password: it
+// follows the rules, and holds no more characters of a class than generated.
+func isGeneratable(gen *generator, pw []rune) bool {
+	counts := make([]int, len(gen.charClasses))
+	for _, r := range pw {
+		if idx := gen.charClassIndex(r); idx >= 0 {
+			counts[idx]++
+		}
+	}
+	for idx, class := range gen.charClasses {
+		if counts[idx] > class.max {
+			return false
+		}
+	}
+	return gen.Validate(string(pw)) == nil
+}
+
+// bruteForceKeyspace counts all the strings of the given length built from the
+// charset that satisfy the given validator.
+func bruteForceKeyspace(cs charset.Charset, length int, valid func(pw []rune) bool) int64 {
+	runes := []rune(cs)
+	indices := make([]int, length)
+	pw := make([]rune, length)
+	count := int64(0)
+	for {
+		for idx, n := range indices {
+			pw[idx] = runes[n]
+		}
+		if valid(pw) {
+			count++
+		}
+
+		// move on to the next combination
+		idx := length - 1
+		for ; idx >= 0; idx-- {
+			indices[idx]++
+			if indices[idx] < len(runes) {
+				break
+			}
+			indices[idx] = 0
+		}
+		if idx < 0 {
+			return count
+		}
+	}
+}
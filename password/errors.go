@@ -1,17 +1,71 @@
 package password
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
-	ErrBufferTooSmall       = errors.New("buffer is too small to hold the generated password")
-	ErrEmptyCharset         = errors.New("cannot generate passwords with empty charset")
-	ErrInvalidN             = errors.New("value of N exceeds valid range")
-	ErrMinLowerCaseTooLong  = errors.New("minimum number of lower-case characters requested longer than password")
-	ErrMinSymbolsTooLong    = errors.New("minimum number of symbols requested longer than password")
-	ErrMinUpperCaseTooLong  = errors.New("minimum number of upper-case characters requested longer than password")
-	ErrNoLowerCaseInCharset = errors.New("found no lower-case characters to use in charset")
-	ErrNoSymbolsInCharset   = errors.New("found no symbols to use in charset")
-	ErrNoUpperCaseInCharset = errors.New("found no upper-case characters to use in charset")
-	ErrRequirementsNotMet   = errors.New("minimum number of lower-case+upper-case+symbols requested longer than password")
-	ErrZeroLenPassword      = errors.New("cannot generate passwords with 0 length")
+	ErrBufferTooSmall          = errors.New("buffer is too small to hold the generated password")
+	ErrCharClassesOverlap      = errors.New("character classes share characters")
+	ErrEmptyCharset            = errors.New("cannot generate passwords with empty charset")
+	ErrEntropyTooLow           = errors.New("entropy of the passwords is lower than the minimum requested")
+	ErrInvalidCharacters       = errors.New("password contains characters not in the charset")
+	ErrExcludedSubstring       = errors.New("password contains an excluded substring")
+	ErrInvalidCharAtPosition   = errors.New("password contains a character not allowed at its position")
+	ErrInvalidN                = errors.New("value of N exceeds valid range")
+	ErrInvalidPosition         = errors.New("position is outside the password")
+	ErrInvalidTemplate         = errors.New("invalid password template")
+	ErrMaxAttemptsExceeded     = errors.New("failed to generate a password satisfying all the rules within the maximum attempts")
+	ErrMinGreaterThanMax       = errors.New("minimum number of characters requested from a class greater than its maximum")
+	ErrMinLowerCaseTooLong     = errors.New("minimum number of lower-case characters requested longer than password")
+	ErrMinNumbersTooLong       = errors.New("minimum number of numbers requested longer than password")
+	ErrMinSymbolsTooLong       = errors.New("minimum number of symbols requested longer than password")
+	ErrMinUpperCaseTooLong     = errors.New("minimum number of upper-case characters requested longer than password")
+	ErrNoCharClassInCharset    = errors.New("found no characters of the class to use in charset")
+	ErrNotEnoughUniqueChars    = errors.New("not enough distinct characters in charset to avoid repeating them")
+	ErrNoLowerCaseInCharset    = errors.New("found no lower-case characters to use in charset")
+	ErrNoNumbersInCharset      = errors.New("found no numbers to use in charset")
+	ErrNoSymbolsInCharset      = errors.New("found no symbols to use in charset")
+	ErrNoUpperCaseInCharset    = errors.New("found no upper-case characters to use in charset")
+	ErrPositionsNotSatisfiable = errors.New("no password can satisfy the position rules along with the other rules")
+	ErrRepeatedCharacter       = errors.New("password repeats a character that must be unique")
+	ErrRequirementsNotMet      = errors.New("minimum number of lower-case+upper-case+numbers+symbols requested longer than password")
+	ErrRequirementsTooStrict   = errors.New("maximum number of characters allowed by the charset and rules shorter than password")
+	ErrRunsNotSatisfiable      = errors.New("no password can avoid the runs of repeated or sequential characters along with the other rules")
+	ErrSequentialRun           = errors.New("password contains a run of sequential characters")
+	ErrTooFewInCharClass       = errors.New("password has too few characters of the class")
+	ErrTooFewLowerCase         = errors.New("password has too few lower-case characters")
+	ErrTooFewNumbers           = errors.New("password has too few numbers")
+	ErrTooFewSymbols           = errors.New("password has too few symbols")
+	ErrTooFewUpperCase         = errors.New("password has too few upper-case characters")
+	ErrTooLong                 = errors.New("password is too long")
+	ErrTooManyInCharClass      = errors.New("password has too many characters of the class")
+	ErrTooManyLowerCase        = errors.New("password has too many lower-case characters")
+	ErrTooManyNumbers          = errors.New("password has too many numbers")
+	ErrTooManySymbols          = errors.New("password has too many symbols")
+	ErrTooManyUpperCase        = errors.New("password has too many upper-case characters")
+	ErrTooManyRepeats          = errors.New("password repeats a character consecutively too many times")
+	ErrTooShort                = errors.New("password is too short")
+	ErrZeroLenPassword         = errors.New("cannot generate passwords with 0 length")
 )
+
+// ValidationError contains every rule violated by a password. Use errors.Is to
+// check for specific violations like ErrTooShort.
+type ValidationError struct {
+	Violations []error
+}
+
+// Error returns all the violations in a single line.
+func (e *ValidationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for idx, err := range e.Violations {
+		violations[idx] = err.Error()
+	}
+	return "invalid password: " + strings.Join(violations, "; ")
+}
+
+// Unwrap returns the violations.
+func (e *ValidationError) Unwrap() []error {
+	return e.Violations
+}
@@ -0,0 +1,328 @@
+package password
+
+import (
+	"fmt"
+	"math/big"
+	"unicode"
+)
+
+var (
+	// maxExcludedStates is the most combinations of matched substrings and
+	// class counts excludedKeyspace follows to count the passwords exactly.
+	maxExcludedStates = 1 << 16
+)
+
+// ExcludedSubstringsError is returned when every password generated within the
+// retry budget contained one of the excluded substrings.
+type ExcludedSubstringsError struct {
+	Attempts   int
+	Substrings []string
+}
+
+// Error returns the number of attempts made, and the excluded substrings.
+func (e *ExcludedSubstringsError) Error() string {
+	return fmt.Sprintf("failed to generate a password without the excluded substrings %q in %d attempts",
+		e.Substrings, e.Attempts)
+}
+
+// Unwrap returns ErrMaxAttemptsExceeded.
+func (e *ExcludedSubstringsError) Unwrap() error {
+	return ErrMaxAttemptsExceeded
+}
+
+// newExcluded returns the excluded substrings as runes, in lower-case if the
+// case is to be ignored.
+func (g *generator) newExcluded() [][]rune {
+	var rsp [][]rune
+	for _, substring := range g.excludedSubstrings {
+		runes := []rune(substring)
+		if g.excludeIgnoreCase {
+			for idx, r := range runes {
+				runes[idx] = unicode.ToLower(r)
+			}
+		}
+		rsp = append(rsp, runes)
+	}
+	return rsp
+}
+
+// containsExcluded returns the index of the first excluded substring found in
+// the password, or -1 if there is none.
+func (g *generator) containsExcluded(password []rune) int {
+	for idx, substring := range g.excluded {
+		for start := 0; start+len(substring) <= len(password); start++ {
+			if g.matchesExcluded(password[start:], substring) {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// matchesExcluded returns true if the password starts with the excluded
+// substring.
+func (g *generator) matchesExcluded(password []rune, substring []rune) bool {
+	for idx, r := range substring {
+		if r2 := password[idx]; r2 != r && !(g.excludeIgnoreCase && unicode.ToLower(r2) == r) {
+			return false
+		}
+	}
+	return true
+}
+
+// excludedKeyspace returns the number of passwords left in the keyspace once
+// the ones with excluded substrings are removed. It is exact unless the
+// characters have to be unique, or there are too many combinations of class
+// counts to follow; it is a lower bound then.
+func (g *generator) excludedKeyspace(keyspace *big.Int) *big.Int {
+	if !g.uniqueChars {
+		if rsp := g.countWithoutExcluded(); rsp != nil {
+			return rsp
+		}
+	}
+	return g.boundExcludedKeyspace(keyspace)
+}
+
+// countWithoutExcluded counts the passwords without any of the excluded
+// substrings one character at a time, following the substrings matched so far
+// and the count of every class with a min or max to honor. It returns nil if
+// there are more than maxExcludedStates such combinations.
+func (g *generator) countWithoutExcluded() *big.Int {
+	a := g.newExcludedAutomaton()
+	counts := g.newClassCounters()
+	numCounts := 1
+	for _, cc := range counts {
+		numCounts *= cc.size
+		if numCounts*len(a.next) > maxExcludedStates {
+			return nil
+		}
+	}
+
+	ways := make([]*big.Int, len(a.next)*numCounts)
+	ways[0] = big.NewInt(1)
+	term := new(big.Int)
+	for idx := 0; idx < g.numChars; idx++ {
+		next := make([]*big.Int, len(ways))
+		for _, step := range g.excludedSteps(idx, a) {
+			for state, w := range ways {
+				if w == nil {
+					continue
+				}
+				node, countsIdx := state/numCounts, state%numCounts
+				nextNode, nextCountsIdx := 0, counts[step.class].next(countsIdx)
+				if step.key >= 0 {
+					nextNode = a.next[node][step.key]
+				}
+				if a.dead[nextNode] || nextCountsIdx < 0 {
+					continue
+				}
+				nextState := nextNode*numCounts + nextCountsIdx
+				if next[nextState] == nil {
+					next[nextState] = new(big.Int)
+				}
+				next[nextState].Add(next[nextState], term.Mul(w, step.numRunes))
+			}
+		}
+		ways = next
+	}
+
+	rsp := new(big.Int)
+	for state, w := range ways {
+		if w != nil && counts.metMinimums(state%numCounts) {
+			rsp.Add(rsp, w)
+		}
+	}
+	return rsp
+}
+
+// boundExcludedKeyspace returns a lower bound of the number of passwords left
+// in the keyspace once the ones with excluded substrings are removed: every
+// window in the password that may match a substring removes at most the
+// passwords with the substring there, whatever the other rules.
+func (g *generator) boundExcludedKeyspace(keyspace *big.Int) *big.Int {
+	numRunes := 0
+	for _, class := range g.charClasses {
+		numRunes += len(class.runes)
+	}
+
+	rsp := new(big.Int).Set(keyspace)
+	for _, substring := range g.excluded {
+		if len(substring) > g.numChars {
+			continue
+		}
+
+		// passwords with the substring in a given window: the ways to match
+		// it, times the ways to fill in the rest of the password
+		numMatches := big.NewInt(1)
+		for _, r := range substring {
+			n := int64(0)
+			for _, class := range g.charClasses {
+				for _, r2 := range class.runes {
+					if r2 == r || (g.excludeIgnoreCase && unicode.ToLower(r2) == r) {
+						n++
+					}
+				}
+			}
+			numMatches.Mul(numMatches, big.NewInt(n))
+		}
+		numRest := int64(g.numChars - len(substring))
+		if g.uniqueChars {
+			numLeft := int64(numRunes - len(substring))
+			numMatches.Mul(numMatches, new(big.Int).MulRange(numLeft-numRest+1, numLeft))
+		} else {
+			numMatches.Mul(numMatches, new(big.Int).Exp(big.NewInt(int64(numRunes)), big.NewInt(numRest), nil))
+		}
+		rsp.Sub(rsp, numMatches.Mul(numMatches, big.NewInt(numRest+1)))
+	}
+	if rsp.Sign() < 0 {
+		rsp.SetInt64(0)
+	}
+	return rsp
+}
+
+// excludedAutomaton matches all the excluded substrings at once (Aho-Corasick)
+// one character at a time, starting from the node 0.
+type excludedAutomaton struct {
+	// alphabet holds the characters in the substrings
+	alphabet map[rune]bool
+	// dead is true for the nodes where a substring has been matched
+	dead []bool
+	// next holds the node to go to from every node for every character in
+	// the alphabet; any other character leads back to the node 0
+	next []map[rune]int
+}
+
+// newExcludedAutomaton returns the automaton matching the excluded substrings.
+func (g *generator) newExcludedAutomaton() *excludedAutomaton {
+	a := &excludedAutomaton{alphabet: make(map[rune]bool), dead: []bool{false}, next: []map[rune]int{{}}}
+	children := []map[rune]int{{}}
+	for _, substring := range g.excluded {
+		node := 0
+		for _, r := range substring {
+			a.alphabet[r] = true
+			child, ok := children[node][r]
+			if !ok {
+				child = len(children)
+				children[node][r] = child
+				children = append(children, map[rune]int{})
+				a.dead, a.next = append(a.dead, false), append(a.next, map[rune]int{})
+			}
+			node = child
+		}
+		a.dead[node] = true
+	}
+
+	// follow the longest suffix of every node that is also a node (its
+	// failure link) to fill in the rest of the transitions, breadth-first so
+	// that the suffixes are done first
+	fail := make([]int, len(children))
+	for queue := []int{0}; len(queue) > 0; queue = queue[1:] {
+		node := queue[0]
+		a.dead[node] = a.dead[node] || a.dead[fail[node]]
+		for r := range a.alphabet {
+			child, ok := children[node][r]
+			switch {
+			case ok && node == 0:
+				queue = append(queue, child)
+			case ok:
+				fail[child] = a.next[fail[node]][r]
+				queue = append(queue, child)
+			case node > 0:
+				child = a.next[fail[node]][r]
+			}
+			a.next[node][r] = child
+		}
+	}
+	return a
+}
+
+// excludedStep is a group of the characters allowed at a position in the
+// password that count towards the same class, and that match the same
+// character of the excluded substrings (none if key is -1).
+type excludedStep struct {
+	class    int
+	key      rune
+	numRunes *big.Int
+}
+
+// excludedSteps returns the groups of characters allowed at the position.
+func (g *generator) excludedSteps(idx int, a *excludedAutomaton) []excludedStep {
+	var runes []rune
+	for _, class := range g.charClasses {
+		runes = append(runes, class.runes...)
+	}
+	for _, pos := range g.positions {
+		if pos.index == idx {
+			runes = pos.allowed
+		}
+	}
+
+	numRunes := make(map[excludedStep]int64)
+	for _, r := range runes {
+		key := r
+		if g.excludeIgnoreCase {
+			key = unicode.ToLower(r)
+		}
+		if !a.alphabet[key] {
+			key = -1
+		}
+		numRunes[excludedStep{class: g.charClassIndex(r), key: key}]++
+	}
+	rsp := make([]excludedStep, 0, len(numRunes))
+	for step, n := range numRunes {
+		step.numRunes = big.NewInt(n)
+		rsp = append(rsp, step)
+	}
+	return rsp
+}
+
+// classCounter follows the count of a class as a digit of a (mixed-radix)
+// index over all the classes. Counts over the max are not allowed, and counts
+// over the min are all the same when the max cannot be reached.
+type classCounter struct {
+	bounded bool
+	min     int
+	size    int
+	stride  int
+}
+
+// classCounters holds a classCounter for every class.
+type classCounters []classCounter
+
+// newClassCounters returns the counters for the classes of the Generator.
+func (g *generator) newClassCounters() classCounters {
+	rsp := make(classCounters, len(g.charClasses))
+	stride := 1
+	for cIdx, class := range g.charClasses {
+		cc := classCounter{min: class.min, size: class.min + 1, stride: stride}
+		if len(class.runes) > 0 && class.max < g.numChars {
+			cc.bounded, cc.size = true, class.max+1
+		}
+		rsp[cIdx] = cc
+		stride *= cc.size
+	}
+	return rsp
+}
+
+// next returns the index with one more character of the class, or -1 if that
+// is more than its max.
+func (cc classCounter) next(idx int) int {
+	switch count := idx / cc.stride % cc.size; {
+	case count+1 < cc.size:
+		return idx + cc.stride
+	case cc.bounded:
+		return -1
+	}
+	return idx
+}
+
+// metMinimums returns true if the index has at least the min count of every
+// class.
+func (ccs classCounters) metMinimums(idx int) bool {
+	for _, cc := range ccs {
+		if idx/cc.stride%cc.size < cc.min {
+			return false
+		}
+	}
+	return true
+}
@@ -2,11 +2,12 @@ package password
 
 import (
 	"fmt"
+	"math"
 	"sync"
-	"unicode"
 	"unicode/utf8"
 
 	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/entropy"
 	"github.com/jedib0t/go-passwords/rng"
 )
 
@@ -14,54 +15,126 @@ var (
 	// storagePoolMinSize is the minimum number of objects to keep in the pool
 	// to support enough parallelism.
 	storagePoolMinSize = 25
+
+	// maxGenerateAttempts is the number of times GenerateTo tries to build a
+	// password that satisfies all the rules before giving up.
+	maxGenerateAttempts = 1000
 )
 
 type Generator interface {
+	// Entropy returns the exact number of distinct passwords that can be
+	// generated, and the bits of entropy it translates to. The rules that
+	// prevent repeated or sequential runs of characters are not accounted
+	// for, and the number is an upper bound when they are in use. Excluded
+	// substrings are accounted for exactly, or with a lower bound when
+	// characters are unique or the class limits are too many to follow.
+	Entropy() entropy.Stats
 	// Generate returns a randomly generated password.
 	Generate() (string, error)
 	// GenerateTo generates a password and writes it to the provided buffer.
 	// It returns the number of bytes written or an error.
 	GenerateTo(buf []byte) (int, error)
+	// Validate checks if the given password follows the rules of the
+	// Generator, and returns a *ValidationError listing every rule the
+	// password violates otherwise.
+	Validate(password string) error
 }
 
 type generator struct {
-	charset           []rune
-	charsetCaseLower  []rune
-	charsetCaseUpper  []rune
-	charsetNonSymbols []rune
-	charsetSymbols    []rune
-	minLowerCase      int
-	minUpperCase      int
-	minSymbols        int
-	maxSymbols        int
-	numChars          int
-	pool              *sync.Pool
+	charClasses        []charClass
+	customCharClasses  []customCharClass
+	charset            []rune
+	excluded           [][]rune
+	excludeIgnoreCase  bool
+	excludedSubstrings []string
+	minLowerCase       int
+	maxLowerCase       int
+	minUpperCase       int
+	maxUpperCase       int
+	minNumbers         int
+	maxNumbers         int
+	minSymbols         int
+	maxSymbols         int
+	maxRepeats         int
+	minEntropy         float64
+	noSequentialRuns   int
+	numChars           int
+	numSymbolsSet      bool
+	placeholders       map[rune]charset.Charset
+	pool               *sync.Pool
+	positionRules      []positionRule
+	positions          []position
+	remainingRunes     []rune
+	rng                rng.Source
+	symbols            charset.Charset
+	uniqueChars        bool
+}
+
+// workspace holds the scratch buffers used to generate a single password.
+type workspace struct {
+	password  []rune
+	indices   []int
+	counts    []int
+	available []rune
+	classes   []int
+	placed    []bool
+	unused    []rune
+	offsets   []int
+	swap      func(i, j int)
+}
+
+func (g *generator) newWorkspace() *workspace {
+	numRunes := 0
+	for _, class := range g.charClasses {
+		numRunes += len(class.runes)
+	}
+
+	w := &workspace{
+		password:  make([]rune, g.numChars),
+		indices:   make([]int, g.numChars),
+		counts:    make([]int, len(g.charClasses)),
+		available: make([]rune, 0, numRunes),
+		classes:   make([]int, 0, numRunes),
+		placed:    make([]bool, g.numChars),
+		unused:    make([]rune, 0, numRunes),
+		offsets:   make([]int, len(g.charClasses)),
+	}
+	for idx, class := range g.charClasses {
+		w.offsets[idx] = len(w.unused)
+		w.unused = append(w.unused, class.runes...)
+	}
+	w.swap = func(i, j int) {
+		w.password[i], w.password[j] = w.password[j], w.password[i]
+	}
+	return w
 }
 
 // NewGenerator returns a password generator that implements the Generator
 // interface.
 func NewGenerator(rules ...Rule) (Generator, error) {
-	g := &generator{}
+	g := &generator{
+		maxLowerCase: math.MaxInt,
+		maxUpperCase: math.MaxInt,
+		maxNumbers:   math.MaxInt,
+	}
 	for _, opt := range append(basicRules, rules...) {
 		opt(g)
 	}
 
-	// split the charsets
-	g.charsetCaseLower = filterRunes(g.charset, unicode.IsLower)
-	g.charsetCaseUpper = filterRunes(g.charset, unicode.IsUpper)
-	g.charsetNonSymbols = filterRunes(g.charset, func(r rune) bool { return !charset.Symbols.Contains(r) })
-	g.charsetSymbols = filterRunes(g.charset, charset.Symbols.Contains)
+	// split the charset into classes
+	g.charClasses = g.newCharClasses()
+	g.remainingRunes = g.newRemainingRunes()
+	g.positions = g.newPositions()
+	g.excluded = g.newExcluded()
 
 	// create a storage pool with enough objects to support enough parallelism
 	g.pool = &sync.Pool{
 		New: func() any {
-			r := make([]rune, g.numChars)
-			return &r
+			return g.newWorkspace()
 		},
 	}
 	for idx := 0; idx < storagePoolMinSize; idx++ {
-		r := make([]rune, g.numChars)
-		g.pool.Put(&r)
+		g.pool.Put(g.newWorkspace())
 	}
 
 	return g.sanitize()
@@ -78,62 +151,174 @@ func (g *generator) Generate() (string, error) {
 }
 
 func (g *generator) GenerateTo(buf []byte) (int, error) {
-	// use the pool to get a []rune for working on
-	passwordPtr := g.pool.Get().(*[]rune)
-	defer g.pool.Put(passwordPtr)
-	password := (*passwordPtr)[:g.numChars]
+	// use the pool to get a workspace for working on
+	ws := g.pool.Get().(*workspace)
+	defer g.pool.Put(ws)
 
-	// fill it with minimum requirements first
-	idx := 0
-	if g.minLowerCase > 0 {
-		if err := g.fill(password, g.charsetCaseLower, g.minLowerCase, &idx); err != nil {
+	password, excluded := ws.password[:g.numChars], false
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		ok, err := g.generate(ws)
+		if err != nil {
 			return 0, err
 		}
-	}
-	if g.minUpperCase > 0 {
-		if err := g.fill(password, g.charsetCaseUpper, g.minUpperCase, &idx); err != nil {
-			return 0, err
+		excluded = ok && g.containsExcluded(password) >= 0
+		if ok && !excluded {
+			// write to the buffer
+			return g.writeToBuf(password, buf)
 		}
 	}
-	if numSymbols, err := g.numSymbolsToGenerate(); err != nil {
-		return 0, err
-	} else if numSymbols > 0 {
-		if err := g.fill(password, g.charsetSymbols, numSymbols, &idx); err != nil {
-			return 0, err
-		}
+	if excluded {
+		return 0, &ExcludedSubstringsError{Attempts: maxGenerateAttempts, Substrings: g.excludedSubstrings}
 	}
-	if remainingChars := len(password) - idx; remainingChars > 0 {
-		if err := g.fill(password, g.charsetNonSymbols, remainingChars, &idx); err != nil {
-			return 0, err
+	return 0, ErrMaxAttemptsExceeded
+}
+
+// generate generates a password in the workspace, and returns false if it
+// could not satisfy all the rules.
+func (g *generator) generate(ws *workspace) (bool, error) {
+	password := ws.password[:g.numChars]
+
+	// fill it with minimum requirements first, followed by the symbols
+	idx := 0
+	clear(ws.counts)
+	for cIdx, class := range g.charClasses {
+		if cIdx != classSymbols && class.min > 0 {
+			if err := g.fill(ws, cIdx, class.min, &idx); err != nil {
+				return false, err
+			}
 		}
 	}
+	if err := g.fillSymbols(ws, &idx); err != nil {
+		return false, err
+	}
+
+	// fill the rest with characters from classes that can take some more
+	if err := g.fillRemaining(ws, &idx); err != nil {
+		return false, err
+	}
 
 	// shuffle it all
-	if err := rng.Shuffle(password); err != nil {
-		return 0, fmt.Errorf("failed to shuffle password: %w", err)
+	if err := g.rng.Shuffle(len(password), ws.swap); err != nil {
+		return false, fmt.Errorf("failed to shuffle password: %w", err)
+	}
+
+	// move the right characters to the constrained positions
+	if ok, err := g.placePositions(ws); !ok || err != nil {
+		return ok, err
 	}
 
-	// write to the buffer
-	return g.writeToBuf(password, buf)
+	// and break up runs of repeated or sequential characters
+	return g.breakRuns(ws)
 }
 
-func (g *generator) fill(password []rune, runes []rune, count int, idx *int) error {
-	var stackBuf [64]int
-	var indices []int = stackBuf[:]
-	if count > len(stackBuf) {
-		indices = make([]int, count)
-	} else {
-		indices = indices[:count]
+// fill fills the password with count characters from the class.
+func (g *generator) fill(ws *workspace, cIdx int, count int, idx *int) error {
+	if g.uniqueChars {
+		return g.fillUnique(ws, cIdx, count, idx)
 	}
 
-	if err := rng.FillIntNs(indices, len(runes)); err != nil {
-		return fmt.Errorf("failed to generate random numbers: %w", err)
+	runes := g.charClasses[cIdx].runes
+	indices := ws.indices[:count]
+	if err := g.randomIndices(indices, len(runes)); err != nil {
+		return err
 	}
 
 	for _, n := range indices {
-		password[*idx] = runes[n]
+		ws.password[*idx] = runes[n]
 		(*idx)++
 	}
+	ws.counts[cIdx] += count
+	return nil
+}
+
+// fillSymbols fills the password with the number of symbols picked for it.
+func (g *generator) fillSymbols(ws *workspace, idx *int) error {
+	numSymbols, err := g.numSymbolsToGenerate()
+	if err != nil {
+		return err
+	}
+	symbols := g.charClasses[classSymbols]
+	numSymbols = min(numSymbols, symbols.max, g.numChars-*idx)
+	if numSymbols == 0 || len(symbols.runes) == 0 {
+		return nil
+	}
+	return g.fill(ws, classSymbols, numSymbols, idx)
+}
+
+// fillRemaining fills the rest of the password with characters from all the
+// classes that have not reached their maximum count yet. Symbols are left out
+// as their count has already been decided.
+func (g *generator) fillRemaining(ws *workspace, idx *int) error {
+	if g.uniqueChars {
+		return g.fillRemainingUnique(ws, idx)
+	}
+
+	// none of the classes can reach its maximum count, so any of the
+	// characters can be used for all of it
+	if g.remainingRunes != nil {
+		indices := ws.indices[:g.numChars-*idx]
+		if err := g.randomIndices(indices, len(g.remainingRunes)); err != nil {
+			return err
+		}
+		for _, n := range indices {
+			ws.password[*idx] = g.remainingRunes[n]
+			(*idx)++
+		}
+		return nil
+	}
+
+	for *idx < g.numChars {
+		// gather the characters that can be used at this point
+		available, classes := ws.available[:0], ws.classes[:0]
+		for cIdx, class := range g.charClasses {
+			if cIdx != classSymbols && ws.counts[cIdx] < class.max {
+				available = append(available, class.runes...)
+				for range class.runes {
+					classes = append(classes, cIdx)
+				}
+			}
+		}
+		if len(available) == 0 {
+			return ErrRequirementsTooStrict
+		}
+
+		// and use them until one of the classes reaches its maximum count
+		indices := ws.indices[:g.numChars-*idx]
+		if err := g.randomIndices(indices, len(available)); err != nil {
+			return err
+		}
+		for _, n := range indices {
+			ws.password[*idx] = available[n]
+			(*idx)++
+			if ws.counts[classes[n]]++; ws.counts[classes[n]] == g.charClasses[classes[n]].max {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// randomIndex returns a random number in [0, n).
+func (g *generator) randomIndex(n int) (int, error) {
+	if n == 1 {
+		return 0, nil
+	}
+	rsp, err := g.rng.IntN(n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate random number: %w", err)
+	}
+	return rsp, nil
+}
+
+// randomIndices fills indices with random numbers in [0, n).
+func (g *generator) randomIndices(indices []int, n int) error {
+	if n == 1 {
+		clear(indices)
+		return nil
+	}
+	if err := g.rng.FillIntNs(indices, n); err != nil {
+		return fmt.Errorf("failed to generate random numbers: %w", err)
+	}
 	return nil
 }
 
@@ -154,7 +339,7 @@ func (g *generator) numSymbolsToGenerate() (int, error) {
 		if g.minSymbols == g.maxSymbols {
 			return g.minSymbols, nil
 		}
-		n, err := rng.IntN(g.maxSymbols - g.minSymbols + 1)
+		n, err := g.rng.IntN(g.maxSymbols - g.minSymbols + 1)
 		if err != nil {
 			return 0, fmt.Errorf("failed to generate random number: %w", err)
 		}
@@ -171,30 +356,78 @@ func (g *generator) sanitize() (Generator, error) {
 	if g.numChars <= 0 {
 		return nil, ErrZeroLenPassword
 	}
-	if g.minLowerCase > 0 && len(g.charsetCaseLower) == 0 {
-		return nil, ErrNoLowerCaseInCharset
+	if err := g.sanitizeCustomCharClasses(); err != nil {
+		return nil, err
 	}
-	if g.minLowerCase > g.numChars {
-		return nil, ErrMinLowerCaseTooLong
+	if err := g.sanitizeRuns(); err != nil {
+		return nil, err
 	}
-	if g.minUpperCase > 0 && len(g.charsetCaseUpper) == 0 {
-		return nil, ErrNoUpperCaseInCharset
+	if err := g.sanitizeMinimums(); err != nil {
+		return nil, err
 	}
-	if g.minUpperCase > g.numChars {
-		return nil, ErrMinUpperCaseTooLong
+	if g.maxFillable() < g.numChars {
+		return nil, ErrRequirementsTooStrict
 	}
-	if g.minSymbols > 0 && len(g.charsetSymbols) == 0 {
-		return nil, ErrNoSymbolsInCharset
+	if err := g.sanitizePositions(); err != nil {
+		return nil, err
 	}
-	if g.minSymbols > g.numChars {
-		return nil, ErrMinSymbolsTooLong
+	if err := g.sanitizeSatisfiable(); err != nil {
+		return nil, err
 	}
-	if g.minLowerCase+g.minUpperCase+g.minSymbols > g.numChars {
-		return nil, ErrRequirementsNotMet
+	if g.minEntropy > 0 && g.Entropy().Bits < g.minEntropy {
+		return nil, ErrEntropyTooLow
 	}
 	return g, nil
 }
 
+// sanitizeMinimums ensures the minimum number of characters requested from
+// each class can be met.
+func (g *generator) sanitizeMinimums() error {
+	for _, req := range []struct {
+		min             int
+		max             int
+		class           charClass
+		errNotInCharset error
+		errTooLong      error
+	}{
+		{g.minLowerCase, g.maxLowerCase, g.charClasses[classLowerCase], ErrNoLowerCaseInCharset, ErrMinLowerCaseTooLong},
+		{g.minUpperCase, g.maxUpperCase, g.charClasses[classUpperCase], ErrNoUpperCaseInCharset, ErrMinUpperCaseTooLong},
+		{g.minNumbers, g.maxNumbers, g.charClasses[classNumbers], ErrNoNumbersInCharset, ErrMinNumbersTooLong},
+		{g.minSymbols, g.maxSymbols, g.charClasses[classSymbols], ErrNoSymbolsInCharset, ErrMinSymbolsTooLong},
+	} {
+		if req.min > 0 && len(req.class.runes) == 0 {
+			return req.errNotInCharset
+		}
+		if req.min > g.numChars {
+			return req.errTooLong
+		}
+		if req.min > req.max {
+			return ErrMinGreaterThanMax
+		}
+	}
+
+	numChars := 0
+	for _, class := range g.charClasses {
+		numChars += class.min
+	}
+	if numChars > g.numChars {
+		return ErrRequirementsNotMet
+	}
+	return nil
+}
+
+// maxFillable returns the maximum number of characters the classes can fill
+// when the least number of symbols is picked.
+func (g *generator) maxFillable() int {
+	rsp := g.minSymbols
+	for cIdx, class := range g.charClasses {
+		if cIdx != classSymbols && len(class.runes) > 0 {
+			rsp += min(class.max, g.numChars)
+		}
+	}
+	return rsp
+}
+
 func filterRunes(runes []rune, truth func(r rune) bool) []rune {
 	var rsp []rune
 	for _, r := range runes {
@@ -28,3 +28,14 @@ func BenchmarkGenerator_GenerateTo(b *testing.B) {
 		_, _ = g.GenerateTo(buf)
 	}
 }
+
+func BenchmarkTemplateGenerator_GenerateTo(b *testing.B) {
+	g, err := NewTemplateGenerator("Cvcc-9999-[A-F]{4}")
+	assert.Nil(b, err)
+	buf := make([]byte, 128)
+
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		_, _ = g.GenerateTo(buf)
+	}
+}
@@ -1,10 +1,15 @@
 package password
 
 import (
+	"errors"
+	"math/rand"
+	"strings"
 	"testing"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/rng"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -117,12 +122,418 @@ func TestGenerator_Generate_WithSymbols(t *testing.T) {
 	})
 }
 
+func TestGenerator_Generate_WithNumbers(t *testing.T) {
+	t.Run("min X", func(t *testing.T) {
+		for _, x := range []int{0, 1, 4, 12} {
+			g, err := NewGenerator(
+				WithCharset(charset.AlphaNumeric),
+				WithLength(12),
+				WithMinNumbers(x),
+			)
+			assert.Nil(t, err)
+
+			for idx := 0; idx < 100; idx++ {
+				password, err := g.Generate()
+				assert.NoError(t, err)
+				assert.Len(t, password, 12)
+
+				numNumbers := len(filterRunes([]rune(password), unicode.IsDigit))
+				assert.True(t, numNumbers >= x, "password: %s, number count: %d", password, numNumbers)
+			}
+		}
+	})
+
+	t.Run("min X max 3", func(t *testing.T) {
+		for _, x := range []int{0, 1, 2, 3} {
+			g, err := NewGenerator(
+				WithCharset(charset.AlphaNumeric),
+				WithLength(12),
+				WithNumNumbers(x, 3),
+			)
+			assert.Nil(t, err)
+
+			for idx := 0; idx < 100; idx++ {
+				password, err := g.Generate()
+				assert.NoError(t, err)
+				assert.Len(t, password, 12)
+
+				numNumbers := len(filterRunes([]rune(password), unicode.IsDigit))
+				assert.True(t, numNumbers >= x, "password: %s, number count: %d", password, numNumbers)
+				assert.True(t, numNumbers <= 3, "password: %s, number count: %d", password, numNumbers)
+			}
+		}
+	})
+
+	t.Run("with everything else", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AllChars),
+			WithLength(8),
+			WithMinLowerCase(2),
+			WithMinUpperCase(2),
+			WithNumNumbers(2, 2),
+			WithNumSymbols(0, 5),
+		)
+		assert.Nil(t, err)
+
+		for idx := 0; idx < 100; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.Len(t, password, 8)
+			assert.Equal(t, 2, len(filterRunes([]rune(password), unicode.IsDigit)), password)
+			assert.True(t, getNumSymbols(password) <= 2, password)
+		}
+	})
+}
+
+func TestGenerator_Generate_WithMaxCounts(t *testing.T) {
+	g, err := NewGenerator(
+		WithCharset(charset.AlphaNumeric+charset.Symbols),
+		WithLength(10),
+		WithNumLowerCase(1, 2),
+		WithNumUpperCase(2, 3),
+		WithNumNumbers(0, 4),
+		WithNumSymbols(1, 3),
+	)
+	assert.Nil(t, err)
+
+	for idx := 0; idx < 1000; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		assert.Len(t, password, 10)
+
+		numLowerCase := len(filterRunes([]rune(password), unicode.IsLower))
+		assert.True(t, numLowerCase >= 1 && numLowerCase <= 2, "password: %s, lower case count: %d", password, numLowerCase)
+		numUpperCase := len(filterRunes([]rune(password), unicode.IsUpper))
+		assert.True(t, numUpperCase >= 2 && numUpperCase <= 3, "password: %s, upper case count: %d", password, numUpperCase)
+		numNumbers := len(filterRunes([]rune(password), unicode.IsDigit))
+		assert.True(t, numNumbers <= 4, "password: %s, number count: %d", password, numNumbers)
+		numSymbols := getNumSymbols(password)
+		assert.True(t, numSymbols >= 1 && numSymbols <= 3, "password: %s, symbol count: %d", password, numSymbols)
+	}
+
+	// caps that add up to the length leave no room for choice
+	g, err = NewGenerator(
+		WithCharset(charset.AlphaNumeric),
+		WithLength(6),
+		WithNumLowerCase(0, 2),
+		WithNumUpperCase(0, 2),
+		WithNumNumbers(0, 2),
+	)
+	assert.Nil(t, err)
+	for idx := 0; idx < 100; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		assert.Len(t, filterRunes([]rune(password), unicode.IsLower), 2, password)
+		assert.Len(t, filterRunes([]rune(password), unicode.IsUpper), 2, password)
+		assert.Len(t, filterRunes([]rune(password), unicode.IsDigit), 2, password)
+	}
+}
+
+func TestGenerator_Generate_WithCharClass(t *testing.T) {
+	countIn := func(password string, cs charset.Charset) int {
+		return len(filterRunes([]rune(password), cs.Contains))
+	}
+
+	g, err := NewGenerator(
+		WithCharset(charset.AlphaNumeric+"_-.жщЖЩ"),
+		WithLength(12),
+		WithCharClass("separators", "_-.", 2, 2),
+		WithCharClass("cyrillic", "жщЖЩ", 1, 1),
+		WithCharClass("vowels", "aeiou", 0, 1),
+		WithMinUpperCase(2),
+	)
+	assert.Nil(t, err)
+
+	for idx := 0; idx < 1000; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		assert.Equal(t, 12, utf8.RuneCountInString(password), password)
+		assert.Equal(t, 2, countIn(password, "_-."), password)
+		assert.Equal(t, 1, countIn(password, "жщЖЩ"), password)
+		assert.True(t, countIn(password, "aeiou") <= 1, password)
+		// the cyrillic upper-case letters do not count as upper-case
+		assert.True(t, countIn(password, charset.AlphabetsUpper) >= 2, password)
+	}
+
+	t.Run("replaced by name", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric+"_-."),
+			WithLength(12),
+			WithCharClass("separators", "_-.", 2, 2),
+			WithCharClass("separators", "_-.", 0, 0),
+		)
+		assert.Nil(t, err)
+		for idx := 0; idx < 100; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.Equal(t, 0, countIn(password, "_-."), password)
+		}
+	})
+}
+
+func TestGenerator_Generate_WithSymbolCharset(t *testing.T) {
+	g, err := NewGenerator(
+		WithCharset(charset.AlphaNumeric+charset.SymbolsFull),
+		WithLength(12),
+		WithNumSymbols(1, 2),
+		WithSymbolCharset(charset.SymbolsFull),
+	)
+	assert.Nil(t, err)
+
+	for idx := 0; idx < 1000; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+
+		numSymbols := len(filterRunes([]rune(password), charset.SymbolsFull.Contains))
+		assert.True(t, numSymbols >= 1 && numSymbols <= 2, "password: %s, symbol count: %d", password, numSymbols)
+	}
+
+	// the default symbols are only a subset of the full set
+	g, err = NewGenerator(
+		WithCharset(charset.AlphaNumeric+"()[]/"),
+		WithNumSymbols(1, 1),
+	)
+	assert.Nil(t, g)
+	assert.Equal(t, ErrNoSymbolsInCharset, err)
+}
+
+func TestGenerator_Generate_WithPositions(t *testing.T) {
+	g, err := NewGenerator(
+		WithCharset(charset.AllChars),
+		WithLength(12),
+		WithNumSymbols(1, 2),
+		WithFirstCharFrom(charset.Alphabets),
+		WithLastCharFrom(charset.Numbers),
+		WithForbiddenAt(1, charset.Symbols),
+		WithForbiddenAt(-2, charset.AlphabetsLower),
+	)
+	assert.Nil(t, err)
+
+	for idx := 0; idx < 1000; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		assert.Len(t, password, 12)
+		assert.True(t, charset.Alphabets.Contains(rune(password[0])), password)
+		assert.True(t, charset.Numbers.Contains(rune(password[11])), password)
+		assert.False(t, charset.Symbols.Contains(rune(password[1])), password)
+		assert.False(t, charset.AlphabetsLower.Contains(rune(password[10])), password)
+		numSymbols := getNumSymbols(password)
+		assert.True(t, numSymbols >= 1 && numSymbols <= 2, "password: %s, symbol count: %d", password, numSymbols)
+	}
+
+	t.Run("remaining characters are shuffled", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphabetsLower+charset.Symbols),
+			WithLength(4),
+			WithNumSymbols(1, 1),
+			WithFirstCharFrom(charset.AlphabetsLower),
+		)
+		assert.Nil(t, err)
+
+		symbolAt := make(map[int]int)
+		for idx := 0; idx < 3000; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.False(t, charset.Symbols.Contains(rune(password[0])), password)
+			for pos, r := range password {
+				if charset.Symbols.Contains(r) {
+					symbolAt[pos]++
+				}
+			}
+		}
+		assert.Equal(t, 0, symbolAt[0])
+		for pos := 1; pos < 4; pos++ {
+			assert.InDelta(t, 1000, symbolAt[pos], 150, "position %d", pos)
+		}
+	})
+
+	t.Run("max attempts exceeded", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithLength(8),
+			WithFirstCharFrom(charset.Numbers),
+		)
+		assert.Nil(t, err)
+
+		maxGenerateAttemptsOrig := maxGenerateAttempts
+		defer func() { maxGenerateAttempts = maxGenerateAttemptsOrig }()
+		maxGenerateAttempts = 0
+		password, err := g.Generate()
+		assert.Empty(t, password)
+		assert.Equal(t, ErrMaxAttemptsExceeded, err)
+	})
+}
+
+func TestGenerator_Generate_WithRuns(t *testing.T) {
+	t.Run("max consecutive repeats", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset("ab"),
+			WithLength(16),
+			WithMaxConsecutiveRepeats(2),
+		)
+		assert.Nil(t, err)
+
+		for idx := 0; idx < 1000; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.Len(t, password, 16)
+			assert.NotContains(t, password, "aaa")
+			assert.NotContains(t, password, "bbb")
+		}
+	})
+
+	t.Run("no sequential runs", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.Numbers),
+			WithLength(16),
+			WithNoSequentialRuns(3),
+			WithFirstCharFrom("0"),
+		)
+		assert.Nil(t, err)
+
+		for idx := 0; idx < 1000; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.Len(t, password, 16)
+			assert.Equal(t, byte('0'), password[0])
+			for pos := 2; pos < len(password); pos++ {
+				diff1, diff2 := int(password[pos-1])-int(password[pos-2]), int(password[pos])-int(password[pos-1])
+				assert.False(t, diff1 == diff2 && (diff1 == 1 || diff1 == -1), password)
+			}
+		}
+	})
+
+	t.Run("unique characters", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric+charset.Symbols),
+			WithLength(20),
+			WithMinLowerCase(4),
+			WithNumUpperCase(2, 8),
+			WithNumNumbers(10, 10),
+			WithNumSymbols(0, 2),
+			WithUniqueCharacters(true),
+		)
+		assert.Nil(t, err)
+
+		for idx := 0; idx < 1000; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.Len(t, password, 20)
+			assert.Len(t, charset.Charset(password).WithoutDuplicates(), 20, password)
+			assert.NoError(t, g.Validate(password), password)
+		}
+	})
+
+	t.Run("everything", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithLength(32),
+			WithMaxConsecutiveRepeats(1),
+			WithNoSequentialRuns(2),
+			WithLastCharFrom(charset.Numbers),
+		)
+		assert.Nil(t, err)
+
+		for idx := 0; idx < 1000; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.NoError(t, g.Validate(password), password)
+		}
+	})
+}
+
+func TestGenerator_Generate_WithExcludedSubstrings(t *testing.T) {
+	g, err := NewGenerator(
+		WithCharset("abcAB"),
+		WithLength(8),
+		WithExcludedSubstrings([]string{"", "aa", "Bb"}),
+		WithExcludedSubstringsIgnoreCase(true),
+	)
+	assert.Nil(t, err)
+
+	for idx := 0; idx < 1000; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		assert.Len(t, password, 8)
+		assert.NotContains(t, strings.ToLower(password), "aa")
+		assert.NotContains(t, strings.ToLower(password), "bb")
+	}
+
+	t.Run("case sensitive", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset("abcAB"),
+			WithLength(8),
+			WithExcludedSubstrings([]string{"aa", "Bb"}),
+		)
+		assert.Nil(t, err)
+
+		foundAA := false
+		for idx := 0; idx < 1000; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.NotContains(t, password, "aa")
+			assert.NotContains(t, password, "Bb")
+			foundAA = foundAA || strings.Contains(password, "AA")
+		}
+		assert.True(t, foundAA)
+	})
+
+	t.Run("retry budget exhausted", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset("ab"),
+			WithLength(8),
+			WithExcludedSubstrings([]string{"a", "b"}),
+		)
+		assert.Nil(t, err)
+
+		password, err := g.Generate()
+		assert.Empty(t, password)
+		assert.True(t, errors.Is(err, ErrMaxAttemptsExceeded))
+		var excludedErr *ExcludedSubstringsError
+		assert.True(t, errors.As(err, &excludedErr))
+		assert.Equal(t, maxGenerateAttempts, excludedErr.Attempts)
+		assert.Equal(t, []string{"a", "b"}, excludedErr.Substrings)
+		assert.EqualError(t, err, `failed to generate a password without the excluded substrings ["a" "b"] in 1000 attempts`)
+	})
+}
+
+func TestGenerator_Generate_WithRandomSource(t *testing.T) {
+	newGenerator := func() Generator {
+		g, err := NewGenerator(
+			WithCharset(charset.AllChars),
+			WithLength(16),
+			WithMinLowerCase(2),
+			WithMinUpperCase(2),
+			WithNumSymbols(1, 3),
+			WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+		)
+		assert.Nil(t, err)
+		return g
+	}
+
+	g1, g2 := newGenerator(), newGenerator()
+	for idx := 0; idx < 100; idx++ {
+		pw1, err := g1.Generate()
+		assert.NoError(t, err)
+		pw2, err := g2.Generate()
+		assert.NoError(t, err)
+		assert.Equal(t, pw1, pw2)
+	}
+
+	g, err := NewGenerator(WithRandomSource(nil))
+	assert.Nil(t, err)
+	pw, err := g.Generate()
+	assert.NoError(t, err)
+	assert.Len(t, pw, 12)
+}
+
 func TestGenerator_numSymbolsToGenerate(t *testing.T) {
 	minSymbols, maxSymbols := 0, 3
 
 	g := &generator{
 		minSymbols: minSymbols,
 		maxSymbols: maxSymbols,
+		rng:        rng.Default(),
 	}
 	for idx := 0; idx < 10000; idx++ {
 		numSymbols, err := g.numSymbolsToGenerate()
@@ -143,6 +554,187 @@ func getNumSymbols(pw string) int {
 }
 
 func TestNewGenerator_ErrorCases(t *testing.T) {
+	t.Run("char classes overlap", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric+"_-."),
+			WithCharClass("separators", "_-.", 1, 2),
+			WithCharClass("dashes", "-", 0, 1),
+		)
+		assert.Nil(t, g)
+		assert.True(t, errors.Is(err, ErrCharClassesOverlap))
+		assert.EqualError(t, err, `character classes share characters: "separators" and "dashes" share '-'`)
+	})
+
+	t.Run("char class not in charset", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithCharClass("separators", "_-.", 1, 2),
+		)
+		assert.Nil(t, g)
+		assert.True(t, errors.Is(err, ErrNoCharClassInCharset))
+	})
+
+	t.Run("char class requirements not met", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric+"_-."),
+			WithLength(4),
+			WithMinLowerCase(2),
+			WithCharClass("separators", "_-.", 3, 3),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrRequirementsNotMet, err)
+
+		g, err = NewGenerator(
+			WithCharset(charset.Numbers+"_-."),
+			WithLength(8),
+			WithNumNumbers(0, 4),
+			WithCharClass("separators", "_-.", 0, 3),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrRequirementsTooStrict, err)
+	})
+
+	t.Run("invalid position", func(t *testing.T) {
+		for _, pos := range []int{12, 13, -13} {
+			g, err := NewGenerator(
+				WithLength(12),
+				WithForbiddenAt(pos, charset.Symbols),
+			)
+			assert.Nil(t, g)
+			assert.True(t, errors.Is(err, ErrInvalidPosition), pos)
+		}
+	})
+
+	t.Run("positions not satisfiable", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithFirstCharFrom(charset.Symbols),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrPositionsNotSatisfiable, err)
+
+		g, err = NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithLength(8),
+			WithNumNumbers(0, 1),
+			WithFirstCharFrom(charset.Numbers),
+			WithLastCharFrom(charset.Numbers),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrPositionsNotSatisfiable, err)
+
+		g, err = NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithLength(3),
+			WithMinUpperCase(2),
+			WithFirstCharFrom(charset.Numbers),
+			WithLastCharFrom(charset.Numbers),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrPositionsNotSatisfiable, err)
+
+		g, err = NewGenerator(
+			WithCharset(charset.AlphabetsLower),
+			WithLength(8),
+			WithFirstCharFrom("ab"),
+			WithForbiddenAt(1, charset.AlphabetsLower[2:]),
+			WithLastCharFrom("ab"),
+			WithUniqueCharacters(true),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrPositionsNotSatisfiable, err)
+	})
+
+	t.Run("runs not satisfiable", func(t *testing.T) {
+		for name, rules := range map[string][]Rule{
+			"no sequential runs": {
+				WithCharset("abc"),
+				WithLength(3),
+				WithNoSequentialRuns(2),
+				WithUniqueCharacters(true),
+			},
+			"max consecutive repeats": {
+				WithCharset("ab"),
+				WithLength(4),
+				WithMaxConsecutiveRepeats(1),
+				WithFirstCharFrom("a"),
+				WithForbiddenAt(1, "b"),
+			},
+			"max consecutive repeats with forbidden chars": {
+				WithCharset("ab1"),
+				WithLength(4),
+				WithMaxConsecutiveRepeats(1),
+				WithFirstCharFrom("a"),
+				WithForbiddenAt(1, "b1"),
+			},
+			"max consecutive repeats with char classes": {
+				WithCharset("ab12"),
+				WithLength(8),
+				WithMaxConsecutiveRepeats(1),
+				WithCharClass("ones", "1", 5, 5),
+			},
+		} {
+			g, err := NewGenerator(rules...)
+			assert.Nil(t, g, name)
+			assert.Equal(t, ErrRunsNotSatisfiable, err, name)
+		}
+	})
+
+	t.Run("runs satisfiable", func(t *testing.T) {
+		for name, rules := range map[string][]Rule{
+			"no sequential runs": {
+				WithCharset("abcd"),
+				WithLength(4),
+				WithNoSequentialRuns(2),
+				WithUniqueCharacters(true),
+			},
+			"max consecutive repeats with char classes": {
+				WithCharset("ab12"),
+				WithLength(8),
+				WithMaxConsecutiveRepeats(1),
+				WithCharClass("ones", "1", 4, 4),
+			},
+			"long password": {
+				WithLength(256),
+				WithMaxConsecutiveRepeats(1),
+				WithNoSequentialRuns(2),
+			},
+		} {
+			g, err := NewGenerator(rules...)
+			assert.Nil(t, err, name)
+			password, err := g.Generate()
+			assert.NoError(t, err, name)
+			assert.Nil(t, g.(*generator).runViolations([]rune(password)), name)
+		}
+	})
+
+	t.Run("not enough unique chars", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.Numbers),
+			WithLength(11),
+			WithUniqueCharacters(true),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrNotEnoughUniqueChars, err)
+
+		g, err = NewGenerator(
+			WithCharset(charset.AlphaNumeric+charset.Symbols),
+			WithLength(12),
+			WithNumSymbols(9, 9),
+			WithUniqueCharacters(true),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrNotEnoughUniqueChars, err)
+
+		g, err = NewGenerator(
+			WithCharset("aaa"),
+			WithLength(4),
+			WithMaxConsecutiveRepeats(3),
+		)
+		assert.Nil(t, g)
+		assert.Equal(t, ErrNotEnoughUniqueChars, err)
+	})
+
 	t.Run("empty charset", func(t *testing.T) {
 		g, err := NewGenerator(WithCharset(charset.Charset("")))
 		assert.Nil(t, g)
@@ -190,6 +782,17 @@ func TestNewGenerator_ErrorCases(t *testing.T) {
 		assert.Equal(t, ErrNoSymbolsInCharset, err)
 	})
 
+	t.Run("no numbers in charset", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.Charset("abcdefABCDEF!@#")),
+			WithLength(12),
+			WithMinNumbers(1),
+		)
+		assert.Nil(t, g)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrNoNumbersInCharset, err)
+	})
+
 	t.Run("min lower case too long", func(t *testing.T) {
 		g, err := NewGenerator(
 			WithCharset(charset.Charset("abcdef")),
@@ -212,6 +815,17 @@ func TestNewGenerator_ErrorCases(t *testing.T) {
 		assert.Equal(t, ErrMinUpperCaseTooLong, err)
 	})
 
+	t.Run("min numbers too long", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.Numbers),
+			WithLength(5),
+			WithNumNumbers(10, 10),
+		)
+		assert.Nil(t, g)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrMinNumbersTooLong, err)
+	})
+
 	t.Run("min symbols too long", func(t *testing.T) {
 		g, err := NewGenerator(
 			WithCharset(charset.Charset("abcdef!@#")),
@@ -223,6 +837,17 @@ func TestNewGenerator_ErrorCases(t *testing.T) {
 		assert.Equal(t, ErrMinSymbolsTooLong, err)
 	})
 
+	t.Run("entropy too low", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.Numbers),
+			WithLength(4),
+			WithMinEntropy(60),
+		)
+		assert.Nil(t, g)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrEntropyTooLow, err)
+	})
+
 	t.Run("requirements not met", func(t *testing.T) {
 		g, err := NewGenerator(
 			WithCharset(charset.Charset("abcdefABCDEF!@#")),
@@ -234,6 +859,51 @@ func TestNewGenerator_ErrorCases(t *testing.T) {
 		assert.Nil(t, g)
 		assert.NotNil(t, err)
 		assert.Equal(t, ErrRequirementsNotMet, err)
+
+		g, err = NewGenerator(
+			WithCharset(charset.Charset("abcdef123")),
+			WithLength(5),
+			WithMinLowerCase(3),
+			WithMinNumbers(3),
+		)
+		assert.Nil(t, g)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrRequirementsNotMet, err)
+	})
+
+	t.Run("min greater than max", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithLength(12),
+			WithNumLowerCase(0, 2),
+			WithMinLowerCase(3),
+		)
+		assert.Nil(t, g)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrMinGreaterThanMax, err)
+	})
+
+	t.Run("requirements too strict", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCharset(charset.Charset("123!@#")),
+			WithLength(8),
+			WithNumNumbers(1, 3),
+			WithNumSymbols(2, 4),
+		)
+		assert.Nil(t, g)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrRequirementsTooStrict, err)
+
+		g, err = NewGenerator(
+			WithCharset(charset.AlphaNumeric),
+			WithLength(12),
+			WithNumLowerCase(0, 3),
+			WithNumUpperCase(0, 3),
+			WithNumNumbers(0, 3),
+		)
+		assert.Nil(t, g)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrRequirementsTooStrict, err)
 	})
 }
 
@@ -304,3 +974,24 @@ func TestNewGenerator_WithBasicRules(t *testing.T) {
 		assert.NotEmpty(t, pw)
 	}
 }
+
+func TestWithMinEntropy(t *testing.T) {
+	// 10^18 ==> ~59.79 bits
+	g, err := NewGenerator(
+		WithCharset(charset.Numbers),
+		WithLength(18),
+		WithMinEntropy(60),
+	)
+	assert.Nil(t, g)
+	assert.Equal(t, ErrEntropyTooLow, err)
+
+	// 10^19 ==> ~63.12 bits
+	g, err = NewGenerator(
+		WithCharset(charset.Numbers),
+		WithLength(19),
+		WithMinEntropy(60),
+	)
+	assert.NotNil(t, g)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, g.Entropy().Bits, 60.0)
+}
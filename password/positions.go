@@ -0,0 +1,273 @@
+package password
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/jedib0t/go-passwords/charset"
+)
+
+// positionRule restricts the characters that can appear at a position in the
+// password; negative positions count backwards from the end.
+type positionRule struct {
+	position  int
+	charset   charset.Charset
+	forbidden bool
+}
+
+// allows returns true if the rule allows the character at its position.
+func (pr positionRule) allows(r rune) bool {
+	return pr.charset.Contains(r) != pr.forbidden
+}
+
+// position is a constrained position in the password along with the
+// characters allowed there by all the rules targeting it.
+type position struct {
+	index   int
+	allowed []rune
+}
+
+// newPositions resolves the position rules into the list of constrained
+// positions, with the most constrained ones first.
+func (g *generator) newPositions() []position {
+	return g.resolvePositions(g.numChars)
+}
+
+// resolvePositions resolves the position rules into the list of constrained
+// positions in a password of the given length, with the most constrained ones
+// first.
+func (g *generator) resolvePositions(length int) []position {
+	uniqueChars := []rune(charset.Charset(g.charset).WithoutDuplicates())
+
+	var positions []position
+	for idx := 0; idx < length; idx++ {
+		var rules []positionRule
+		for _, pr := range g.positionRules {
+			if pr.position == idx || pr.position+length == idx {
+				rules = append(rules, pr)
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		positions = append(positions, position{
+			index: idx,
+			allowed: filterRunes(uniqueChars, func(r rune) bool {
+				for _, pr := range rules {
+					if !pr.allows(r) {
+						return false
+					}
+				}
+				return true
+			}),
+		})
+	}
+	slices.SortStableFunc(positions, func(a, b position) int {
+		return len(a.allowed) - len(b.allowed)
+	})
+	return positions
+}
+
+// placePositions moves characters around the (already shuffled) password so
+// that every constrained position holds a character allowed there. Each one is
+// picked at random from the characters that have not been placed yet, and
+// false is returned if none of them are allowed at a position.
+func (g *generator) placePositions(ws *workspace) (bool, error) {
+	password, placed := ws.password[:g.numChars], ws.placed[:g.numChars]
+	clear(placed)
+	for _, pos := range g.positions {
+		numCandidates := 0
+		for idx, r := range password {
+			if !placed[idx] && slices.Contains(pos.allowed, r) {
+				numCandidates++
+			}
+		}
+		if numCandidates == 0 {
+			return false, nil
+		}
+
+		pick, err := g.randomIndex(numCandidates)
+		if err != nil {
+			return false, err
+		}
+		for idx, r := range password {
+			if !placed[idx] && slices.Contains(pos.allowed, r) {
+				if pick == 0 {
+					password[idx], password[pos.index] = password[pos.index], password[idx]
+					break
+				}
+				pick--
+			}
+		}
+		placed[pos.index] = true
+	}
+	return true, nil
+}
+
+// positionViolations returns the errors for every constrained position in the
+// password holding a character not allowed there.
+func (g *generator) positionViolations(password []rune) []error {
+	positions := g.positions
+	if len(password) != g.numChars {
+		// negative positions count backwards from the end of this password
+		positions = g.resolvePositions(len(password))
+	}
+
+	var violations []error
+	for _, pos := range positions {
+		if r := password[pos.index]; !slices.Contains(pos.allowed, r) {
+			violations = append(violations, fmt.Errorf("%w: %q at position %d", ErrInvalidCharAtPosition, r, pos.index))
+		}
+	}
+	return violations
+}
+
+// sanitizePositions ensures the position rules target positions within the
+// password, and that they can be met along with the rest of the rules.
+func (g *generator) sanitizePositions() error {
+	for _, pr := range g.positionRules {
+		if pr.position >= g.numChars || pr.position < -g.numChars {
+			return fmt.Errorf("%w: %d", ErrInvalidPosition, pr.position)
+		}
+	}
+	if len(g.positions) > 0 && !g.canFillPositions() {
+		return ErrPositionsNotSatisfiable
+	}
+	// the classes tell nothing of the characters used, so with unique
+	// characters also ensure every constrained position can hold a distinct one
+	if g.uniqueChars && !g.canPlaceUnique() {
+		return ErrPositionsNotSatisfiable
+	}
+	return nil
+}
+
+// canFillPositions returns true if every constrained position can hold an
+// allowed character while honoring the min/max count of each class. With the
+// positions grouped by the classes allowed there, this holds if and only if,
+// for every set of classes, the positions limited to the set fit within its
+// max counts, and the positions that can use the set cover its min counts.
+func (g *generator) canFillPositions() bool {
+	// the unconstrained positions allow every class with characters
+	var allClasses uint64
+	for cIdx, class := range g.charClasses {
+		if len(class.runes) > 0 {
+			allClasses |= 1 << cIdx
+		}
+	}
+	numPositions := map[uint64]int{allClasses: g.numChars - len(g.positions)}
+	for _, pos := range g.positions {
+		var classes uint64
+		for _, r := range pos.allowed {
+			classes |= 1 << g.charClassIndex(r)
+		}
+		numPositions[classes]++
+	}
+
+	for set := allClasses; ; set = (set - 1) & allClasses {
+		minCount, maxCount := 0, 0
+		for cIdx, class := range g.charClasses {
+			if set&(1<<cIdx) != 0 {
+				minCount, maxCount = minCount+class.min, maxCount+class.max
+			}
+		}
+		numWithin, numTouching := 0, 0
+		for classes, n := range numPositions {
+			if classes&^set == 0 {
+				numWithin += n
+			}
+			if classes&set != 0 {
+				numTouching += n
+			}
+		}
+		if numWithin > maxCount || numTouching < minCount {
+			return false
+		}
+		if set == 0 {
+			return true
+		}
+	}
+}
+
+// canPlaceUnique returns true if every constrained position can hold a
+// distinct character, by finding a matching between the positions and the
+// characters allowed at them.
+func (g *generator) canPlaceUnique() bool {
+	matchedTo := make(map[rune]int, len(g.positions))
+	var tryPlace func(pIdx int, visited map[rune]bool) bool
+	tryPlace = func(pIdx int, visited map[rune]bool) bool {
+		for _, r := range g.positions[pIdx].allowed {
+			if visited[r] {
+				continue
+			}
+			visited[r] = true
+			if other, ok := matchedTo[r]; !ok || tryPlace(other, visited) {
+				matchedTo[r] = pIdx
+				return true
+			}
+		}
+		return false
+	}
+
+	for pIdx := range g.positions {
+		if !tryPlace(pIdx, make(map[rune]bool)) {
+			return false
+		}
+	}
+	return true
+}
+
+// positionsKeyspace returns the number of distinct passwords like keyspace
+// does, while honoring the characters allowed at the constrained positions.
+// With unique characters, the characters at the constrained positions are not
+// excluded from the rest of the password, and so this is an upper bound.
+func (g *generator) positionsKeyspace() *big.Int {
+	type state struct {
+		counts []int
+		ways   *big.Int
+	}
+
+	// fill the constrained positions first while tracking the number of
+	// characters used from each class
+	states := map[string]state{
+		"": {counts: make([]int, len(g.charClasses)), ways: big.NewInt(1)},
+	}
+	for _, pos := range g.positions {
+		numAllowed := make([]int64, len(g.charClasses))
+		for _, r := range pos.allowed {
+			numAllowed[g.charClassIndex(r)]++
+		}
+
+		next := make(map[string]state)
+		for _, s := range states {
+			for cIdx, n := range numAllowed {
+				if n == 0 || s.counts[cIdx] >= g.charClasses[cIdx].max {
+					continue
+				}
+				counts := slices.Clone(s.counts)
+				counts[cIdx]++
+				key := fmt.Sprint(counts)
+				if _, ok := next[key]; !ok {
+					next[key] = state{counts: counts, ways: new(big.Int)}
+				}
+				next[key].ways.Add(next[key].ways, new(big.Int).Mul(s.ways, big.NewInt(n)))
+			}
+		}
+		states = next
+	}
+
+	// and then the rest of the password with what is left of each class
+	rsp := new(big.Int)
+	classes := make([]charClass, len(g.charClasses))
+	for _, s := range states {
+		for cIdx, class := range g.charClasses {
+			class.min = max(class.min-s.counts[cIdx], 0)
+			class.max -= s.counts[cIdx]
+			classes[cIdx] = class
+		}
+		ways := keyspace(g.numChars-len(g.positions), classes, g.uniqueChars)
+		rsp.Add(rsp, ways.Mul(ways, s.ways))
+	}
+	return rsp
+}
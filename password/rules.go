@@ -1,6 +1,9 @@
 package password
 
-import "github.com/jedib0t/go-passwords/charset"
+import (
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/rng"
+)
 
 // Rule controls how the Generator/Sequencer generates passwords.
 type Rule func(g *generator)
@@ -9,9 +12,42 @@ var (
 	basicRules = []Rule{
 		WithCharset(charset.AllChars),
 		WithLength(12),
+		WithRandomSource(rng.Default()),
+		WithSymbolCharset(charset.Symbols),
 	}
 )
 
+// WithCharClass declares a named class of characters that must appear between
+// min and max times in the password. Only the characters of the class that are
+// also in the charset are used, and they count only towards this class and not
+// towards the lower case, upper case, number or symbol counts. Declaring a
+// class with an existing name replaces it.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithCharClass(name string, cs charset.Charset, min, max int) Rule {
+	// sanitize min and max
+	if min < 0 {
+		min = 0
+	}
+	if max < 0 {
+		max = 0
+	}
+	if min > max {
+		min = max
+	}
+
+	return func(g *generator) {
+		cc := customCharClass{name: name, charset: cs, min: min, max: max}
+		for idx := range g.customCharClasses {
+			if g.customCharClasses[idx].name == name {
+				g.customCharClasses[idx] = cc
+				return
+			}
+		}
+		g.customCharClasses = append(g.customCharClasses, cc)
+	}
+}
+
 // WithCharset sets the Charset the Generator/Sequencer can use.
 func WithCharset(c charset.Charset) Rule {
 	return func(g *generator) {
@@ -19,6 +55,67 @@ func WithCharset(c charset.Charset) Rule {
 	}
 }
 
+// WithExcludedSubstrings prevents the given substrings (like user names) from
+// appearing in the passwords. Passwords containing any of them are discarded
+// and generated again, up to a limited number of attempts before GenerateTo
+// gives up with an *ExcludedSubstringsError.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithExcludedSubstrings(substrings []string) Rule {
+	// ignore empty substrings as they would match every password
+	var nonEmpty []string
+	for _, substring := range substrings {
+		if substring != "" {
+			nonEmpty = append(nonEmpty, substring)
+		}
+	}
+
+	return func(g *generator) {
+		g.excludedSubstrings = nonEmpty
+	}
+}
+
+// WithExcludedSubstringsIgnoreCase controls whether the substrings set using
+// WithExcludedSubstrings are matched regardless of their case.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithExcludedSubstringsIgnoreCase(ignoreCase bool) Rule {
+	return func(g *generator) {
+		g.excludeIgnoreCase = ignoreCase
+	}
+}
+
+// WithFirstCharFrom restricts the first character of the password to the
+// characters in the given Charset.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithFirstCharFrom(c charset.Charset) Rule {
+	return func(g *generator) {
+		g.positionRules = append(g.positionRules, positionRule{position: 0, charset: c})
+	}
+}
+
+// WithForbiddenAt prevents the characters in the given Charset from appearing
+// at the given (0-based) position in the password; negative positions count
+// backwards from the end, with -1 being the last character.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithForbiddenAt(position int, c charset.Charset) Rule {
+	return func(g *generator) {
+		g.positionRules = append(g.positionRules, positionRule{position: position, charset: c, forbidden: true})
+	}
+}
+
+// WithLastCharFrom restricts the last character of the password to the
+// characters in the given Charset.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithLastCharFrom(c charset.Charset) Rule {
+	return func(g *generator) {
+		g.positionRules = append(g.positionRules, positionRule{position: -1, charset: c})
+	}
+}
+
 // WithLength sets the length of the generated password.
 func WithLength(l int) Rule {
 	return func(g *generator) {
@@ -26,6 +123,34 @@ func WithLength(l int) Rule {
 	}
 }
 
+// WithMaxConsecutiveRepeats limits the number of times a character can repeat
+// consecutively in the password; for ex., 2 allows "aa" but not "aaa". 0
+// removes the limit.
+//
+// NewGenerator returns ErrRunsNotSatisfiable if no password can avoid such
+// repeats along with the rest of the rules (ex.: "ab" with a limit of 1, the
+// first character from "a" and "b" forbidden at the second one).
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithMaxConsecutiveRepeats(n int) Rule {
+	if n < 0 {
+		n = 0
+	}
+
+	return func(g *generator) {
+		g.maxRepeats = n
+	}
+}
+
+// WithMinEntropy ensures the Generator is configured to generate passwords
+// with at least the given bits of entropy; NewGenerator returns
+// ErrEntropyTooLow otherwise.
+func WithMinEntropy(bits float64) Rule {
+	return func(g *generator) {
+		g.minEntropy = bits
+	}
+}
+
 // WithMinLowerCase controls the minimum number of lower case characters that
 // can appear in the password.
 //
@@ -36,6 +161,16 @@ func WithMinLowerCase(min int) Rule {
 	}
 }
 
+// WithMinNumbers controls the minimum number of numbers that can appear in the
+// password.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithMinNumbers(min int) Rule {
+	return func(g *generator) {
+		g.minNumbers = min
+	}
+}
+
 // WithMinUpperCase controls the minimum number of upper case characters that
 // can appear in the password.
 //
@@ -46,10 +181,73 @@ func WithMinUpperCase(min int) Rule {
 	}
 }
 
-// WithNumSymbols controls the min/max number of symbols that can appear in the
+// WithNoSequentialRuns prevents runs of n or more sequential digits or
+// letters (of the same case) in either direction; for ex., 3 allows "ab" and
+// "21" but not "abc" or "321". Values below 2 remove the restriction.
+//
+// NewGenerator returns ErrRunsNotSatisfiable if no password can avoid such
+// runs along with the rest of the rules (ex.: the charset "abc" with unique
+// characters and runs of 2).
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithNoSequentialRuns(n int) Rule {
+	if n < 2 {
+		n = 0
+	}
+
+	return func(g *generator) {
+		g.noSequentialRuns = n
+	}
+}
+
+// WithNumLowerCase controls the min/max number of lower case characters that
+// can appear in the password.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithNumLowerCase(min, max int) Rule {
+	// sanitize min and max
+	if min < 0 {
+		min = 0
+	}
+	if max < 0 {
+		max = 0
+	}
+	if min > max {
+		min = max
+	}
+
+	return func(g *generator) {
+		g.minLowerCase = min
+		g.maxLowerCase = max
+	}
+}
+
+// WithNumNumbers controls the min/max number of numbers that can appear in the
 // password.
 //
 // Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithNumNumbers(min, max int) Rule {
+	// sanitize min and max
+	if min < 0 {
+		min = 0
+	}
+	if max < 0 {
+		max = 0
+	}
+	if min > max {
+		min = max
+	}
+
+	return func(g *generator) {
+		g.minNumbers = min
+		g.maxNumbers = max
+	}
+}
+
+// WithNumSymbols controls the min/max number of symbols that can appear in the
+// password. The characters treated as symbols are set using WithSymbolCharset.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
 func WithNumSymbols(min, max int) Rule {
 	// sanitize min and max
 	if min < 0 {
@@ -65,5 +263,79 @@ func WithNumSymbols(min, max int) Rule {
 	return func(g *generator) {
 		g.minSymbols = min
 		g.maxSymbols = max
+		g.numSymbolsSet = true
+	}
+}
+
+// WithNumUpperCase controls the min/max number of upper case characters that
+// can appear in the password.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithNumUpperCase(min, max int) Rule {
+	// sanitize min and max
+	if min < 0 {
+		min = 0
+	}
+	if max < 0 {
+		max = 0
+	}
+	if min > max {
+		min = max
+	}
+
+	return func(g *generator) {
+		g.minUpperCase = min
+		g.maxUpperCase = max
+	}
+}
+
+// WithPlaceholder adds a placeholder to the templates of the template
+// Generator, replaced by a random character from the given Charset; it
+// overrides the built-in placeholder of the same rune if any.
+//
+// Note: This works only on a template Generator (see NewTemplateGenerator).
+func WithPlaceholder(placeholder rune, c charset.Charset) Rule {
+	return func(g *generator) {
+		if g.placeholders == nil {
+			g.placeholders = make(map[rune]charset.Charset)
+		}
+		g.placeholders[placeholder] = c
+	}
+}
+
+// WithRandomSource sets the source of randomness used to generate passwords.
+// A nil Source resets it to the default buffered crypto/rand Source.
+func WithRandomSource(src rng.Source) Rule {
+	if src == nil {
+		src = rng.Default()
+	}
+
+	return func(g *generator) {
+		g.rng = src
+	}
+}
+
+// WithSymbolCharset sets the characters that are treated as symbols, and are
+// thus counted against the limits set using WithNumSymbols. Defaults to
+// charset.Symbols; use charset.SymbolsFull or any custom set of characters to
+// count those as symbols instead.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithSymbolCharset(c charset.Charset) Rule {
+	return func(g *generator) {
+		g.symbols = c
+	}
+}
+
+// WithUniqueCharacters makes the Generator use every character at most once
+// in a password.
+//
+// NewGenerator returns ErrPositionsNotSatisfiable if the position rules cannot
+// be met with distinct characters.
+//
+// Note: This works only on a Generator and is ineffective with a Sequencer.
+func WithUniqueCharacters(unique bool) Rule {
+	return func(g *generator) {
+		g.uniqueChars = unique
 	}
 }
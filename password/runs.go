@@ -0,0 +1,146 @@
+package password
+
+import (
+	"fmt"
+	"slices"
+	"unicode"
+)
+
+// breakRuns moves characters around so that the password has no runs of
+// repeated or sequential characters longer than allowed. Every character that
+// breaks a rule is swapped with a random character further along that does
+// not, and false is returned if there is no such character.
+func (g *generator) breakRuns(ws *workspace) (bool, error) {
+	if g.maxRepeats == 0 && g.noSequentialRuns == 0 {
+		return true, nil
+	}
+
+	password, placed := ws.password[:g.numChars], ws.placed[:g.numChars]
+	for idx, r := range password {
+		if !g.breaksRun(password, idx, r) {
+			continue
+		}
+		if placed[idx] {
+			return false, nil
+		}
+
+		numCandidates := 0
+		for idx2 := idx + 1; idx2 < len(password); idx2++ {
+			if !placed[idx2] && !g.breaksRun(password, idx, password[idx2]) {
+				numCandidates++
+			}
+		}
+		if numCandidates == 0 {
+			return false, nil
+		}
+
+		pick, err := g.randomIndex(numCandidates)
+		if err != nil {
+			return false, err
+		}
+		for idx2 := idx + 1; idx2 < len(password); idx2++ {
+			if !placed[idx2] && !g.breaksRun(password, idx, password[idx2]) {
+				if pick == 0 {
+					password[idx], password[idx2] = password[idx2], password[idx]
+					break
+				}
+				pick--
+			}
+		}
+	}
+	return true, nil
+}
+
+// breaksRun returns true if placing the character at idx completes a run of
+// repeated or sequential characters longer than allowed, given the characters
+// before it.
+func (g *generator) breaksRun(password []rune, idx int, r rune) bool {
+	return g.breaksRepeats(password, idx, r) || g.breaksSequence(password, idx, r)
+}
+
+// breaksRepeats returns true if placing the character at idx repeats it
+// consecutively more times than allowed.
+func (g *generator) breaksRepeats(password []rune, idx int, r rune) bool {
+	if g.maxRepeats == 0 {
+		return false
+	}
+
+	runLen := 1
+	for prev := idx - 1; prev >= 0 && password[prev] == r; prev-- {
+		runLen++
+	}
+	return runLen > g.maxRepeats
+}
+
+// breaksSequence returns true if placing the character at idx completes a run
+// of sequential characters that is not allowed.
+func (g *generator) breaksSequence(password []rune, idx int, r rune) bool {
+	if g.noSequentialRuns == 0 {
+		return false
+	}
+
+	for _, step := range [2]rune{1, -1} {
+		runLen, next := 1, r
+		for prev := idx - 1; prev >= 0 && isSequential(password[prev], next, step); prev-- {
+			runLen, next = runLen+1, password[prev]
+		}
+		if runLen >= g.noSequentialRuns {
+			return true
+		}
+	}
+	return false
+}
+
+// isSequential returns true if b follows a in a sequence of digits, or of
+// letters of the same case, going in the direction of step.
+func isSequential(a, b rune, step rune) bool {
+	if b-a != step {
+		return false
+	}
+	return (unicode.IsDigit(a) && unicode.IsDigit(b)) ||
+		(unicode.IsLower(a) && unicode.IsLower(b)) ||
+		(unicode.IsUpper(a) && unicode.IsUpper(b))
+}
+
+// sanitizeRuns ensures there are enough distinct characters to use each of
+// them at most once, or to avoid repeating them.
+func (g *generator) sanitizeRuns() error {
+	numUnique := 0
+	for _, class := range g.charClasses {
+		numUnique += len(class.runes)
+	}
+
+	if g.uniqueChars {
+		if g.numChars > numUnique {
+			return ErrNotEnoughUniqueChars
+		}
+		for _, class := range g.charClasses {
+			if len(class.runes) > 0 && class.min > len(class.runes) {
+				return ErrNotEnoughUniqueChars
+			}
+		}
+	}
+	if g.maxRepeats > 0 && g.numChars > g.maxRepeats && numUnique < 2 {
+		return ErrNotEnoughUniqueChars
+	}
+	return nil
+}
+
+// runViolations returns the errors for every character in the password that
+// is repeated when it should be unique, or that completes a run of repeated or
+// sequential characters.
+func (g *generator) runViolations(password []rune) []error {
+	var violations []error
+	for idx, r := range password {
+		if g.uniqueChars && slices.Contains(password[:idx], r) {
+			violations = append(violations, fmt.Errorf("%w: %q at position %d", ErrRepeatedCharacter, r, idx))
+		}
+		if g.breaksRepeats(password, idx, r) {
+			violations = append(violations, fmt.Errorf("%w: %q at position %d", ErrTooManyRepeats, r, idx))
+		}
+		if g.breaksSequence(password, idx, r) {
+			violations = append(violations, fmt.Errorf("%w: %q at position %d", ErrSequentialRun, r, idx))
+		}
+	}
+	return violations
+}
@@ -0,0 +1,135 @@
+package password
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jedib0t/go-passwords/charset"
+)
+
+var (
+	// maxSearchSteps is the number of characters canGenerate tries before
+	// giving up, and letting the rules through.
+	maxSearchSteps = 1 << 18
+)
+
+// passwordSearch looks for a password satisfying all the rules (but the
+// excluded substrings) one character at a time, going back a character when
+// the rest of the password cannot be filled.
+type passwordSearch struct {
+	g        *generator
+	allowed  [][]rune
+	classes  map[rune]int
+	counts   []int
+	failed   map[string]bool
+	password []rune
+	steps    int
+	used     map[rune]bool
+}
+
+// sanitizeSatisfiable ensures some password satisfies the rules on runs of
+// repeated or sequential characters, and on unique characters at constrained
+// positions, along with the rest of the rules; the other rules are checked on
+// their own.
+func (g *generator) sanitizeSatisfiable() error {
+	runs := g.maxRepeats > 0 || g.noSequentialRuns > 0
+	if !runs && !(g.uniqueChars && len(g.positions) > 0) {
+		return nil
+	}
+	if g.canGenerate() {
+		return nil
+	}
+	if runs {
+		return ErrRunsNotSatisfiable
+	}
+	return ErrPositionsNotSatisfiable
+}
+
+// canGenerate returns true if some password satisfies all the rules (but the
+// excluded substrings), or if there are too many combinations of characters
+// to rule them all out.
+func (g *generator) canGenerate() bool {
+	uniqueChars := []rune(charset.Charset(g.charset).WithoutDuplicates())
+	s := &passwordSearch{
+		g:        g,
+		allowed:  make([][]rune, g.numChars),
+		classes:  make(map[rune]int, len(uniqueChars)),
+		counts:   make([]int, len(g.charClasses)),
+		failed:   make(map[string]bool),
+		password: make([]rune, 0, g.numChars),
+		used:     make(map[rune]bool),
+	}
+	for idx := range s.allowed {
+		s.allowed[idx] = uniqueChars
+	}
+	for _, pos := range g.positions {
+		s.allowed[pos.index] = pos.allowed
+	}
+	for _, r := range uniqueChars {
+		s.classes[r] = g.charClassIndex(r)
+	}
+
+	found := s.fill()
+	return found || s.steps >= maxSearchSteps
+}
+
+// fill returns true if the rest of the password can be filled, and leaves the
+// password as is if not.
+func (s *passwordSearch) fill() bool {
+	idx := len(s.password)
+	if idx == s.g.numChars {
+		return true
+	}
+	key := s.key()
+	if s.failed[key] {
+		return false
+	}
+
+	for _, r := range s.allowed[idx] {
+		if s.steps >= maxSearchSteps {
+			return false
+		}
+		cIdx := s.classes[r]
+		if s.counts[cIdx] >= s.g.charClasses[cIdx].max || s.used[r] || s.g.breaksRun(s.password, idx, r) {
+			continue
+		}
+
+		s.steps++
+		s.password, s.counts[cIdx], s.used[r] = append(s.password, r), s.counts[cIdx]+1, s.g.uniqueChars
+		if s.canFillRest() && s.fill() {
+			return true
+		}
+		s.password, s.counts[cIdx], s.used[r] = s.password[:idx], s.counts[cIdx]-1, false
+	}
+	s.failed[key] = true
+	return false
+}
+
+// canFillRest returns true if the rest of the password is long enough for the
+// minimum count of every class, and short enough for the maximum counts.
+func (s *passwordSearch) canFillRest() bool {
+	numLeft, numNeeded, numAvailable := s.g.numChars-len(s.password), 0, 0
+	for cIdx, class := range s.g.charClasses {
+		numNeeded += max(class.min-s.counts[cIdx], 0)
+		if s.g.uniqueChars {
+			numAvailable += min(class.max, len(class.runes)) - s.counts[cIdx]
+		} else if len(class.runes) > 0 {
+			numAvailable += class.max - s.counts[cIdx]
+		}
+	}
+	return numNeeded <= numLeft && numAvailable >= numLeft
+}
+
+// key returns what the rest of the password depends on: the position, the
+// count of every class, the characters that may be part of a run, and the
+// characters used if they have to be unique.
+func (s *passwordSearch) key() string {
+	tail := s.password[max(len(s.password)-max(s.g.maxRepeats, s.g.noSequentialRuns), 0):]
+	key := fmt.Sprint(len(s.password), s.counts, string(tail))
+	if s.g.uniqueChars {
+		used := slices.Clone(s.password)
+		slices.Sort(used)
+		key += string(used)
+	}
+	return key
+}
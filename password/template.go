@@ -0,0 +1,295 @@
+package password
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/entropy"
+)
+
+const (
+	// maxTemplateLength is the maximum number of characters in the passwords
+	// generated from a template, so that a count like "9{1000000000}" cannot
+	// exhaust the memory.
+	maxTemplateLength = 1024
+)
+
+var (
+	// templatePlaceholders holds the placeholders a template can use out of
+	// the box.
+	templatePlaceholders = map[rune]charset.Charset{
+		'*': charset.AllChars,
+		'9': charset.Numbers,
+		'A': charset.AlphabetsUpper,
+		'C': "BCDFGHJKLMNPQRSTVWXYZ",
+		'H': "0123456789ABCDEF",
+		'L': charset.Alphabets,
+		'V': "AEIOU",
+		'X': charset.AlphabetsUpper + charset.Numbers,
+		'a': charset.AlphabetsLower,
+		'c': "bcdfghjklmnpqrstvwxyz",
+		'd': charset.Numbers,
+		'h': "0123456789abcdef",
+		's': charset.Symbols,
+		'v': "aeiou",
+		'x': charset.AlphabetsLower + charset.Numbers,
+	}
+)
+
+// templateToken is a single character in the generated passwords: either a
+// literal, or one picked from the runes.
+type templateToken struct {
+	literal rune
+	runes   []rune
+}
+
+// templateGenerator generates passwords following a template; it embeds the
+// generator the Rules are applied on.
+type templateGenerator struct {
+	*generator
+	numBytes int
+	tokens   []templateToken
+}
+
+// NewTemplateGenerator returns a password generator that implements the
+// Generator interface, and generates passwords following the given template.
+// Every character in the template is one of:
+// * a placeholder replaced by a random character from its Charset:
+//   - 'a'/'A': lower/upper-case letters, 'L': letters of either case
+//   - 'c'/'C': lower/upper-case consonants, 'v'/'V': lower/upper-case vowels
+//   - '9' and 'd': numbers
+//   - 'h'/'H': lower/upper-case hexadecimal digits
+//   - 'x'/'X': lower/upper-case letters and numbers
+//   - 's': symbols
+//   - '*': any character from charset.AllChars
+//
+// * a set of characters within brackets, with ranges (ex.: "[A-F]", "[xyz]")
+// * a count within braces repeating the previous character (ex.: "9{4}")
+// * a literal character, escaped with a backslash if it is a placeholder or
+// one of the special characters (ex.: "\a", "\[")
+//
+// For ex., "Cvcc-9999-[A-F]{4}" generates passwords like "Bofd-4821-CAFE".
+// The passwords cannot be longer than 1024 characters.
+//
+// Placeholders can be added or overridden using WithPlaceholder. Only the
+// WithMinEntropy, WithPlaceholder and WithRandomSource rules apply.
+func NewTemplateGenerator(template string, rules ...Rule) (Generator, error) {
+	g := &generator{}
+	for _, opt := range append(basicRules, rules...) {
+		opt(g)
+	}
+
+	placeholders := make(map[rune][]rune, len(templatePlaceholders)+len(g.placeholders))
+	for placeholder, cs := range templatePlaceholders {
+		placeholders[placeholder] = []rune(cs)
+	}
+	for placeholder, cs := range g.placeholders {
+		if cs = cs.WithoutDuplicates(); cs == "" {
+			return nil, fmt.Errorf("%w: placeholder %q", ErrEmptyCharset, placeholder)
+		}
+		placeholders[placeholder] = []rune(cs)
+	}
+
+	tokens, err := parseTemplate([]rune(template), placeholders)
+	if err != nil {
+		return nil, err
+	}
+	t := &templateGenerator{generator: g, tokens: tokens}
+	for _, token := range tokens {
+		t.numBytes += token.maxRuneLen()
+	}
+	if g.minEntropy > 0 && t.Entropy().Bits < g.minEntropy {
+		return nil, ErrEntropyTooLow
+	}
+	return t, nil
+}
+
+// Entropy returns the exact number of distinct passwords the Generator can
+// generate, and the bits of entropy it translates to.
+func (t *templateGenerator) Entropy() entropy.Stats {
+	keyspace := big.NewInt(1)
+	for _, token := range t.tokens {
+		if token.runes != nil {
+			keyspace.Mul(keyspace, big.NewInt(int64(len(token.runes))))
+		}
+	}
+	return entropy.New(keyspace)
+}
+
+// Generate returns a randomly generated password.
+func (t *templateGenerator) Generate() (string, error) {
+	buf := make([]byte, t.numBytes)
+	n, err := t.GenerateTo(buf)
+	if err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+// GenerateTo generates a password and writes it to the provided buffer.
+// It returns the number of bytes written or an error.
+func (t *templateGenerator) GenerateTo(buf []byte) (int, error) {
+	offset := 0
+	for _, token := range t.tokens {
+		r := token.literal
+		if token.runes != nil {
+			n, err := t.randomIndex(len(token.runes))
+			if err != nil {
+				return 0, err
+			}
+			r = token.runes[n]
+		}
+
+		if offset+utf8.RuneLen(r) > len(buf) {
+			return 0, ErrBufferTooSmall
+		}
+		offset += utf8.EncodeRune(buf[offset:], r)
+	}
+	return offset, nil
+}
+
+// Validate checks if the given password could have been generated by the
+// Generator, and returns a *ValidationError listing every character that does
+// not follow the template otherwise.
+func (t *templateGenerator) Validate(password string) error {
+	var violations []error
+	runes := []rune(password)
+	if len(runes) < len(t.tokens) {
+		violations = append(violations, fmt.Errorf("%w: found %d characters, need %d", ErrTooShort, len(runes), len(t.tokens)))
+	} else if len(runes) > len(t.tokens) {
+		violations = append(violations, fmt.Errorf("%w: found %d characters, need %d", ErrTooLong, len(runes), len(t.tokens)))
+	}
+
+	for idx, r := range runes[:min(len(runes), len(t.tokens))] {
+		if !t.tokens[idx].matches(r) {
+			violations = append(violations, fmt.Errorf("%w: %q at position %d", ErrInvalidCharAtPosition, r, idx))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// matches returns true if the token could have generated the given rune.
+func (t templateToken) matches(r rune) bool {
+	if t.runes == nil {
+		return r == t.literal
+	}
+	return slices.Contains(t.runes, r)
+}
+
+// maxRuneLen returns the number of bytes needed to encode any rune the token
+// can generate.
+func (t templateToken) maxRuneLen() int {
+	rsp := utf8.RuneLen(t.literal)
+	for _, r := range t.runes {
+		rsp = max(rsp, utf8.RuneLen(r))
+	}
+	return rsp
+}
+
+// parseTemplate returns the tokens in the given template.
+func parseTemplate(template []rune, placeholders map[rune][]rune) ([]templateToken, error) {
+	var tokens []templateToken
+	canRepeat := false
+	for idx := 0; idx < len(template); idx++ {
+		var token templateToken
+		switch r := template[idx]; {
+		case r == '\\':
+			if idx++; idx == len(template) {
+				return nil, fmt.Errorf("%w: nothing to escape at position %d", ErrInvalidTemplate, idx-1)
+			}
+			token.literal = template[idx]
+		case r == '[':
+			end, runes, err := parseTemplateSet(template, idx)
+			if err != nil {
+				return nil, err
+			}
+			token.runes, idx = runes, end
+		case r == '{':
+			if !canRepeat {
+				return nil, fmt.Errorf("%w: nothing to repeat at position %d", ErrInvalidTemplate, idx)
+			}
+			end, count, err := parseTemplateCount(template, idx)
+			if err != nil {
+				return nil, err
+			}
+			if len(tokens)-1+count > maxTemplateLength {
+				return nil, fmt.Errorf("%w: count %q at position %d makes it longer than %d characters",
+					ErrInvalidTemplate, string(template[idx:end+1]), idx, maxTemplateLength)
+			}
+			for n := 1; n < count; n++ {
+				tokens = append(tokens, tokens[len(tokens)-1])
+			}
+			idx, canRepeat = end, false
+			continue
+		case placeholders[r] != nil:
+			token.runes = placeholders[r]
+		default:
+			token.literal = r
+		}
+		tokens = append(tokens, token)
+		canRepeat = true
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidTemplate)
+	}
+	if len(tokens) > maxTemplateLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidTemplate, maxTemplateLength)
+	}
+	return tokens, nil
+}
+
+// parseTemplateSet parses the set of characters within brackets starting at
+// the given index, and returns the index of the closing bracket along with
+// the characters.
+func parseTemplateSet(template []rune, start int) (int, []rune, error) {
+	var set []rune
+	for idx := start + 1; idx < len(template); idx++ {
+		r := template[idx]
+		switch {
+		case r == ']':
+			if len(set) == 0 {
+				return 0, nil, fmt.Errorf("%w: empty set at position %d", ErrInvalidTemplate, start)
+			}
+			return idx, []rune(charset.Charset(set).WithoutDuplicates()), nil
+		case r == '\\' && idx+1 < len(template):
+			idx++
+			set = append(set, template[idx])
+		case idx+2 < len(template) && template[idx+1] == '-' && template[idx+2] != ']':
+			if template[idx+2] < r {
+				return 0, nil, fmt.Errorf("%w: invalid range %q at position %d", ErrInvalidTemplate, string(template[idx:idx+3]), idx)
+			}
+			for c := r; c <= template[idx+2]; c++ {
+				set = append(set, c)
+			}
+			idx += 2
+		default:
+			set = append(set, r)
+		}
+	}
+	return 0, nil, fmt.Errorf("%w: unterminated set at position %d", ErrInvalidTemplate, start)
+}
+
+// parseTemplateCount parses the count within braces starting at the given
+// index, and returns the index of the closing brace along with the count.
+func parseTemplateCount(template []rune, start int) (int, int, error) {
+	end := slices.Index(template[start:], '}')
+	if end < 0 {
+		return 0, 0, fmt.Errorf("%w: unterminated count at position %d", ErrInvalidTemplate, start)
+	}
+	end += start
+
+	count, err := strconv.Atoi(string(template[start+1 : end]))
+	if err != nil || count < 1 {
+		return 0, 0, fmt.Errorf("%w: invalid count %q at position %d", ErrInvalidTemplate, string(template[start:end+1]), start)
+	}
+	return end, count, nil
+}
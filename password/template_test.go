@@ -0,0 +1,142 @@
+package password
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/rng"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTemplateGenerator(t *testing.T) {
+	for template, pattern := range map[string]string{
+		"Cvcc-9999-[A-F]{4}": `^[B-DF-HJ-NP-TV-Z][aeiou][b-df-hj-np-tv-z]{2}-[0-9]{4}-[A-F]{4}$`,
+		"XXXX-XXXX-XXXX":     `^[A-Z0-9]{4}-[A-Z0-9]{4}-[A-Z0-9]{4}$`,
+		"Aaaa9999":           `^[A-Z][a-z]{3}[0-9]{4}$`,
+		"LLdd-ss":            `^[A-Za-z]{2}[0-9]{2}-[!@#$%^&*]{2}$`,
+		"hH{2}x*":            `^[0-9a-f][0-9A-F]{2}[a-z0-9].$`,
+		`i\d:\a\[[-_]\\`:     `^id:a\[[-_]\\$`,
+		"[a\\]z]{3}":         `^[a\]z]{3}$`,
+		"ключ-9":             `^ключ-[0-9]$`,
+	} {
+		t.Run(template, func(t *testing.T) {
+			g, err := NewTemplateGenerator(template)
+			assert.Nil(t, err)
+
+			re := regexp.MustCompile(pattern)
+			for idx := 0; idx < 100; idx++ {
+				password, err := g.Generate()
+				assert.NoError(t, err)
+				assert.Regexp(t, re, password)
+				assert.NoError(t, g.Validate(password))
+			}
+		})
+	}
+}
+
+func TestNewTemplateGenerator_WithPlaceholder(t *testing.T) {
+	g, err := NewTemplateGenerator("AAA-###",
+		WithPlaceholder('#', "αβγ"),
+		WithPlaceholder('A', "xy"),
+	)
+	assert.Nil(t, err)
+
+	for idx := 0; idx < 100; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		assert.Regexp(t, `^[xy]{3}-[αβγ]{3}$`, password)
+	}
+	assert.Equal(t, big.NewInt(8*27), g.Entropy().Keyspace)
+
+	_, err = NewTemplateGenerator("###", WithPlaceholder('#', ""))
+	assert.True(t, errors.Is(err, ErrEmptyCharset))
+	assert.EqualError(t, err, `cannot generate passwords with empty charset: placeholder '#'`)
+}
+
+func TestNewTemplateGenerator_Errors(t *testing.T) {
+	for template, message := range map[string]string{
+		"":                        "invalid password template: empty",
+		"abc\\":                   "invalid password template: nothing to escape at position 3",
+		"[]":                      "invalid password template: empty set at position 0",
+		"a[bc":                    "invalid password template: unterminated set at position 1",
+		"[z-a]":                   `invalid password template: invalid range "z-a" at position 1`,
+		"{2}":                     "invalid password template: nothing to repeat at position 0",
+		"a{2}{2}":                 "invalid password template: nothing to repeat at position 4",
+		"a{2":                     "invalid password template: unterminated count at position 1",
+		"a{0}":                    `invalid password template: invalid count "{0}" at position 1`,
+		"a{x}":                    `invalid password template: invalid count "{x}" at position 1`,
+		"9999{1,2}x":              `invalid password template: invalid count "{1,2}" at position 4`,
+		"9{1000000000}":           `invalid password template: count "{1000000000}" at position 1 makes it longer than 1024 characters`,
+		"a{1000}b{24}c{2}":        `invalid password template: count "{2}" at position 13 makes it longer than 1024 characters`,
+		strings.Repeat("a", 1025): "invalid password template: longer than 1024 characters",
+	} {
+		t.Run(template, func(t *testing.T) {
+			g, err := NewTemplateGenerator(template)
+			assert.Nil(t, g)
+			assert.True(t, errors.Is(err, ErrInvalidTemplate))
+			assert.EqualError(t, err, message)
+		})
+	}
+
+	g, err := NewTemplateGenerator("9999", WithMinEntropy(14))
+	assert.Nil(t, g)
+	assert.Equal(t, ErrEntropyTooLow, err)
+}
+
+func TestTemplateGenerator_Entropy(t *testing.T) {
+	g, err := NewTemplateGenerator("Cvcc-9999-[A-F]{4}")
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(21*5*21*21*10000*6*6*6*6), g.Entropy().Keyspace)
+
+	// literals and duplicates in sets add nothing
+	g, err = NewTemplateGenerator("---[aab]")
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(2), g.Entropy().Keyspace)
+
+	// the keyspace must match the number of distinct passwords generated
+	g, err = NewTemplateGenerator("[ab]-v9",
+		WithPlaceholder('9', "123"),
+		WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+	)
+	assert.Nil(t, err)
+	seen := make(map[string]bool)
+	for idx := 0; idx < 10000; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		seen[password] = true
+	}
+	assert.Equal(t, int64(len(seen)), g.Entropy().Keyspace.Int64())
+}
+
+func TestTemplateGenerator_GenerateTo(t *testing.T) {
+	g, err := NewTemplateGenerator("ключ-9{4}")
+	assert.Nil(t, err)
+
+	buf := make([]byte, 13)
+	n, err := g.GenerateTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 13, n)
+	assert.Regexp(t, `^ключ-[0-9]{4}$`, string(buf[:n]))
+
+	n, err = g.GenerateTo(make([]byte, 12))
+	assert.Equal(t, ErrBufferTooSmall, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestTemplateGenerator_Validate(t *testing.T) {
+	g, err := NewTemplateGenerator("Aa-9{2}", WithCharset(charset.Numbers))
+	assert.Nil(t, err)
+
+	assert.NoError(t, g.Validate("Ab-12"))
+	assert.EqualError(t, g.Validate("ab_1"), "invalid password: "+
+		"password is too short: found 4 characters, need 5; "+
+		"password contains a character not allowed at its position: 'a' at position 0; "+
+		"password contains a character not allowed at its position: '_' at position 2")
+	assert.EqualError(t, g.Validate("Ab-123"), "invalid password: "+
+		"password is too long: found 6 characters, need 5")
+}
@@ -0,0 +1,59 @@
+package password
+
+// fillUnique fills the password with count characters from the class that
+// have not been used yet.
+func (g *generator) fillUnique(ws *workspace, cIdx int, count int, idx *int) error {
+	for ; count > 0; count-- {
+		n, err := g.randomIndex(len(g.charClasses[cIdx].runes) - ws.counts[cIdx])
+		if err != nil {
+			return err
+		}
+		g.useUnused(ws, cIdx, n, idx)
+	}
+	return nil
+}
+
+// fillRemainingUnique fills the rest of the password like fillRemaining does,
+// while using every character at most once.
+func (g *generator) fillRemainingUnique(ws *workspace, idx *int) error {
+	for *idx < g.numChars {
+		numUnused := 0
+		for cIdx, class := range g.charClasses {
+			if cIdx != classSymbols && ws.counts[cIdx] < class.max {
+				numUnused += len(class.runes) - ws.counts[cIdx]
+			}
+		}
+		if numUnused == 0 {
+			return ErrRequirementsTooStrict
+		}
+
+		n, err := g.randomIndex(numUnused)
+		if err != nil {
+			return err
+		}
+		for cIdx, class := range g.charClasses {
+			if cIdx != classSymbols && ws.counts[cIdx] < class.max {
+				if numUnusedInClass := len(class.runes) - ws.counts[cIdx]; n >= numUnusedInClass {
+					n -= numUnusedInClass
+					continue
+				}
+				g.useUnused(ws, cIdx, n, idx)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// useUnused appends the n-th unused character of the class to the password,
+// and marks it as used. The characters of every class are kept in the unused
+// buffer with the used ones moved to the front.
+func (g *generator) useUnused(ws *workspace, cIdx int, n int, idx *int) {
+	start := ws.offsets[cIdx]
+	unused := ws.unused[start+ws.counts[cIdx] : start+len(g.charClasses[cIdx].runes)]
+	unused[0], unused[n] = unused[n], unused[0]
+
+	ws.password[*idx] = unused[0]
+	(*idx)++
+	ws.counts[cIdx]++
+}
@@ -0,0 +1,64 @@
+package password
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Validate checks if the given password follows the given rules, which are
+// applied exactly as NewGenerator does. It returns the error from NewGenerator
+// if the rules are invalid, or a *ValidationError listing every rule the
+// password violates.
+func Validate(password string, rules ...Rule) error {
+	g, err := NewGenerator(rules...)
+	if err != nil {
+		return err
+	}
+	return g.Validate(password)
+}
+
+// Validate checks if the given password follows the rules of the Generator,
+// and returns a *ValidationError listing every rule the password violates
+// otherwise. The length is a minimum, and the classes are limited only by the
+// max counts set by the rules, so that longer passwords are valid too.
+func (g *generator) Validate(password string) error {
+	var violations []error
+	if numChars := utf8.RuneCountInString(password); numChars < g.numChars {
+		violations = append(violations, fmt.Errorf("%w: found %d characters, need at least %d", ErrTooShort, numChars, g.numChars))
+	}
+
+	// count the characters in each class
+	counts := make([]int, len(g.charClasses))
+	var invalidChars []rune
+	for _, r := range password {
+		if idx := g.charClassIndex(r); idx >= 0 {
+			counts[idx]++
+		} else {
+			invalidChars = append(invalidChars, r)
+		}
+	}
+	if len(invalidChars) > 0 {
+		violations = append(violations, fmt.Errorf("%w: %q", ErrInvalidCharacters, string(invalidChars)))
+	}
+
+	runes := []rune(password)
+	violations = append(violations, g.positionViolations(runes)...)
+	violations = append(violations, g.runViolations(runes)...)
+	if idx := g.containsExcluded(runes); idx >= 0 {
+		violations = append(violations, fmt.Errorf("%w: %q", ErrExcludedSubstring, g.excludedSubstrings[idx]))
+	}
+
+	for idx, class := range g.charClasses {
+		if counts[idx] < class.min && class.errTooFew != nil {
+			violations = append(violations, fmt.Errorf("%w: found %d, need at least %d", class.errTooFew, counts[idx], class.min))
+		}
+		if counts[idx] > class.limit && class.errTooMany != nil {
+			violations = append(violations, fmt.Errorf("%w: found %d, need at most %d", class.errTooMany, counts[idx], class.limit))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
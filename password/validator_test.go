@@ -0,0 +1,183 @@
+package password
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	rules := []Rule{
+		WithCharset(charset.AllChars.WithoutAmbiguity()),
+		WithLength(12),
+		WithMinLowerCase(5),
+		WithMinUpperCase(2),
+		WithNumSymbols(1, 1),
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, Validate("abcdeFGhjk2#", rules...))
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		err := Validate("abcdeFGhjk2#", WithLength(0))
+		assert.Equal(t, ErrZeroLenPassword, err)
+	})
+
+	t.Run("every violation", func(t *testing.T) {
+		err := Validate("abcdEFGHI0#$ö", rules...)
+		assert.Error(t, err)
+
+		var validationErr *ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Violations, 3)
+		assert.True(t, errors.Is(err, ErrInvalidCharacters))
+		assert.True(t, errors.Is(err, ErrTooFewLowerCase))
+		assert.True(t, errors.Is(err, ErrTooManySymbols))
+		assert.False(t, errors.Is(err, ErrTooShort))
+		assert.False(t, errors.Is(err, ErrTooFewUpperCase))
+		assert.Equal(t, "invalid password: "+
+			"password contains characters not in the charset: \"I0ö\"; "+
+			"password has too few lower-case characters: found 4, need at least 5; "+
+			"password has too many symbols: found 2, need at most 1",
+			err.Error())
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		err := Validate("abcDE", rules...)
+		assert.True(t, errors.Is(err, ErrTooShort))
+		assert.ErrorContains(t, err, "password is too short: found 5 characters, need at least 12")
+		assert.True(t, errors.Is(err, ErrTooFewSymbols))
+		assert.False(t, errors.Is(err, ErrTooFewUpperCase))
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		err := Validate("abcdEFGHJK2#", WithCharset(charset.AllChars.WithoutAmbiguity()),
+			WithLength(12), WithNumUpperCase(1, 3), WithNumLowerCase(4, 6))
+		assert.True(t, errors.Is(err, ErrTooManyUpperCase))
+		assert.False(t, errors.Is(err, ErrTooFewLowerCase))
+		assert.False(t, errors.Is(err, ErrTooManyLowerCase))
+	})
+
+	t.Run("longer", func(t *testing.T) {
+		assert.NoError(t, Validate("abcdeFGhjk2#mnpqrstuvwxyzABCD", rules...))
+		assert.NoError(t, Validate("abcdefghijkmnopqrstuvwxyz", WithLength(12)))
+		assert.NoError(t, Validate("abc#$%^&*def", WithLength(12)))
+
+		err := Validate("abcdeFGHJKLMNPQR2#", WithCharset(charset.AllChars.WithoutAmbiguity()),
+			WithLength(12), WithNumUpperCase(1, 10), WithNumSymbols(0, 0))
+		assert.True(t, errors.Is(err, ErrTooManyUpperCase))
+		assert.True(t, errors.Is(err, ErrTooManySymbols))
+		assert.False(t, errors.Is(err, ErrTooLong))
+	})
+
+	t.Run("symbol charset", func(t *testing.T) {
+		rules := []Rule{
+			WithCharset(charset.AlphaNumeric + charset.SymbolsFull),
+			WithLength(8),
+			WithNumSymbols(1, 1),
+			WithSymbolCharset(charset.SymbolsFull),
+		}
+		assert.NoError(t, Validate("abcd(efg", rules...))
+		assert.True(t, errors.Is(Validate("abc(efg]", rules...), ErrTooManySymbols))
+		assert.True(t, errors.Is(Validate("abcdefg1", rules...), ErrTooFewSymbols))
+	})
+
+	t.Run("positions", func(t *testing.T) {
+		rules := []Rule{
+			WithCharset(charset.AlphaNumeric),
+			WithLength(6),
+			WithFirstCharFrom(charset.Alphabets),
+			WithForbiddenAt(-1, charset.Numbers),
+		}
+		assert.NoError(t, Validate("a1b2cd", rules...))
+
+		assert.NoError(t, Validate("a1b2cd3e", rules...))
+		assert.True(t, errors.Is(Validate("a1b2cde3", rules...), ErrInvalidCharAtPosition))
+
+		err := Validate("1abcd2", rules...)
+		assert.True(t, errors.Is(err, ErrInvalidCharAtPosition))
+		assert.EqualError(t, err, "invalid password: "+
+			"password contains a character not allowed at its position: '1' at position 0; "+
+			"password contains a character not allowed at its position: '2' at position 5")
+	})
+
+	t.Run("runs", func(t *testing.T) {
+		rules := []Rule{
+			WithCharset(charset.AlphaNumeric),
+			WithLength(8),
+			WithMaxConsecutiveRepeats(2),
+			WithNoSequentialRuns(3),
+			WithUniqueCharacters(true),
+		}
+		assert.NoError(t, Validate("a1b2c3d4", rules...))
+
+		err := Validate("aaa321xy", rules...)
+		assert.True(t, errors.Is(err, ErrRepeatedCharacter))
+		assert.True(t, errors.Is(err, ErrTooManyRepeats))
+		assert.True(t, errors.Is(err, ErrSequentialRun))
+		assert.EqualError(t, err, "invalid password: "+
+			"password repeats a character that must be unique: 'a' at position 1; "+
+			"password repeats a character that must be unique: 'a' at position 2; "+
+			"password repeats a character consecutively too many times: 'a' at position 2; "+
+			"password contains a run of sequential characters: '1' at position 5")
+	})
+
+	t.Run("excluded substrings", func(t *testing.T) {
+		rules := []Rule{
+			WithCharset(charset.AlphaNumeric),
+			WithLength(8),
+			WithExcludedSubstrings([]string{"john", "acme"}),
+			WithExcludedSubstringsIgnoreCase(true),
+		}
+		assert.NoError(t, Validate("jo1hnacm", rules...))
+		assert.EqualError(t, Validate("12JoHn34", rules...),
+			`invalid password: password contains an excluded substring: "john"`)
+	})
+
+	t.Run("char class", func(t *testing.T) {
+		rules := []Rule{
+			WithCharset(charset.AlphaNumeric + "_-."),
+			WithLength(8),
+			WithCharClass("separators", "_-.", 1, 2),
+		}
+		assert.NoError(t, Validate("abc_def1", rules...))
+
+		err := Validate("abcdefg1", rules...)
+		assert.True(t, errors.Is(err, ErrTooFewInCharClass))
+		assert.EqualError(t, err, `invalid password: password has too few characters of the class "separators": found 0, need at least 1`)
+
+		err = Validate("a-b_c.d1", rules...)
+		assert.True(t, errors.Is(err, ErrTooManyInCharClass))
+	})
+}
+
+func TestGenerator_Validate(t *testing.T) {
+	g, err := NewGenerator(
+		WithCharset(charset.AllChars.WithoutAmbiguity().WithoutDuplicates()),
+		WithLength(16),
+		WithMinLowerCase(3),
+		WithMinUpperCase(3),
+		WithNumNumbers(1, 2),
+		WithNumSymbols(1, 4),
+	)
+	assert.Nil(t, err)
+
+	// every generated password must be valid
+	for idx := 0; idx < 1000; idx++ {
+		pw, err := g.Generate()
+		assert.NoError(t, err)
+		assert.NoError(t, g.Validate(pw), pw)
+	}
+
+	err = g.Validate("aaaaaaaaaaaaaaaa")
+	assert.True(t, errors.Is(err, ErrTooFewUpperCase))
+	assert.True(t, errors.Is(err, ErrTooFewSymbols))
+	assert.True(t, errors.Is(err, ErrTooFewNumbers))
+
+	err = g.Validate("abcABC!234567890")
+	assert.True(t, errors.Is(err, ErrTooManyNumbers))
+	assert.False(t, errors.Is(err, ErrTooFewSymbols))
+}
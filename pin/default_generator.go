@@ -0,0 +1,14 @@
+package pin
+
+var (
+	defaultGenerator, _ = NewGenerator(
+		WithLength(6),
+	)
+)
+
+// Generate generates and returns a PIN that follows the following rules:
+// * ensures the PIN is 6 digits long
+// * rejects repeats, sequences, dates and commonly used PINs
+func Generate() (string, error) {
+	return defaultGenerator.Generate()
+}
@@ -0,0 +1,14 @@
+package pin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	pin, err := Generate()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pin)
+	assert.Equal(t, 6, len(pin), "PIN should be 6 digits long")
+}
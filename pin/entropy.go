@@ -0,0 +1,152 @@
+package pin
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/jedib0t/go-passwords/entropy"
+)
+
+// Entropy returns the exact number of distinct PINs the Generator can
+// generate, and the bits of entropy it translates to.
+func (g *generator) Entropy() entropy.Stats {
+	keyspace := big.NewInt(int64(pow10[g.numDigits]))
+	keyspace.Sub(keyspace, big.NewInt(g.numWeak()))
+	return entropy.New(keyspace)
+}
+
+// numWeak returns the number of PINs the Generator rejects as weak.
+func (g *generator) numWeak() int64 {
+	var count int64
+	if g.rejectRepeats {
+		count = int64(pow10[g.numDigits]) - numAperiodic(g.numDigits)
+	}
+
+	// the rest of the weak PINs are few enough to be listed
+	for pin := range g.weakCandidates() {
+		if g.rejectRepeats && isRepeated([]byte(pin)) {
+			continue // already counted
+		}
+		if g.weakness([]byte(pin)) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// numAperiodic returns the number of PINs of the given length that are not
+// made up of a repeated block of digits, using the Möbius inversion of
+// 10^n = Σ aperiodic(d) for every d dividing n.
+func numAperiodic(length int) int64 {
+	var rsp int64
+	for d := 1; d <= length; d++ {
+		if length%d == 0 {
+			rsp += int64(mobius(length/d)) * int64(pow10[d])
+		}
+	}
+	return rsp
+}
+
+// mobius returns the Möbius function of n: 0 if n has a squared prime factor,
+// or else 1/-1 for an even/odd number of prime factors.
+func mobius(n int) int {
+	rsp := 1
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			n /= p
+			if n%p == 0 {
+				return 0
+			}
+			rsp = -rsp
+		}
+	}
+	if n > 1 {
+		rsp = -rsp
+	}
+	return rsp
+}
+
+// weakCandidates returns the sequences, dates and common PINs of the
+// Generator's length, as enabled.
+func (g *generator) weakCandidates() map[string]bool {
+	rsp := make(map[string]bool)
+	if g.rejectSequences {
+		for start := 0; start < 10; start++ {
+			for _, step := range []int{1, 9} {
+				var sb strings.Builder
+				for idx := 0; idx < g.numDigits; idx++ {
+					sb.WriteByte(byte('0' + (start+idx*step)%10))
+				}
+				rsp[sb.String()] = true
+			}
+		}
+	}
+	if g.rejectDates {
+		for _, layout := range dateLayouts[g.numDigits] {
+			for _, date := range datesInLayout(layout) {
+				rsp[date] = true
+			}
+		}
+	}
+	if g.rejectCommon {
+		for pin := range commonPINs {
+			if len(pin) == g.numDigits {
+				rsp[pin] = true
+			}
+		}
+	}
+	return rsp
+}
+
+// datesInLayout returns all the valid dates in the given layout.
+func datesInLayout(layout string) []string {
+	years, yearLayout := []int{0}, ""
+	if strings.Contains(layout, "YYYY") {
+		years, yearLayout = numbersInRange(minYear, maxYear), "YYYY"
+	} else if strings.Contains(layout, "YY") {
+		years, yearLayout = numbersInRange(0, 99), "YY"
+	}
+	months := []int{0}
+	if strings.Contains(layout, "MM") {
+		months = numbersInRange(1, 12)
+	}
+
+	var rsp []string
+	for _, year := range years {
+		for _, month := range months {
+			days := []int{0}
+			if strings.Contains(layout, "DD") {
+				days = numbersInRange(1, daysInMonth[month])
+			}
+			for _, day := range days {
+				date := []byte(layout)
+				fillNumber(date, yearLayout, year)
+				fillNumber(date, "MM", month)
+				fillNumber(date, "DD", day)
+				rsp = append(rsp, string(date))
+			}
+		}
+	}
+	return rsp
+}
+
+// fillNumber replaces the placeholder in the date with the zero-padded
+// number.
+func fillNumber(date []byte, placeholder string, number int) {
+	idx := strings.Index(string(date), placeholder)
+	if placeholder == "" || idx < 0 {
+		return
+	}
+	for pos := idx + len(placeholder) - 1; pos >= idx; pos-- {
+		date[pos] = byte('0' + number%10)
+		number /= 10
+	}
+}
+
+func numbersInRange(lo, hi int) []int {
+	rsp := make([]int, 0, hi-lo+1)
+	for n := lo; n <= hi; n++ {
+		rsp = append(rsp, n)
+	}
+	return rsp
+}
@@ -0,0 +1,63 @@
+package pin
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Entropy(t *testing.T) {
+	t.Run("nothing rejected", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithLength(8),
+			WithRejectCommon(false),
+			WithRejectDates(false),
+			WithRejectRepeats(false),
+			WithRejectSequences(false),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(100000000), g.Entropy().Keyspace)
+	})
+
+	t.Run("only repeats", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithLength(12),
+			WithRejectCommon(false),
+			WithRejectDates(false),
+			WithRejectSequences(false),
+		)
+		assert.Nil(t, err)
+		// aperiodic strings of length 12: 10^12 - 10^6 - 10^4 + 10^2
+		assert.Equal(t, big.NewInt(1000000000000-1000000-10000+100), g.Entropy().Keyspace)
+	})
+
+	// the keyspace must match the number of PINs that are not weak when all
+	// of them can be checked
+	for _, length := range []int{4, 5, 6} {
+		for _, flags := range []int{0b1111, 0b1110, 0b1101, 0b1011, 0b0111, 0b0001} {
+			t.Run(fmt.Sprintf("%d digits/%04b", length, flags), func(t *testing.T) {
+				g, err := NewGenerator(
+					WithLength(length),
+					WithRejectCommon(flags&0b1000 != 0),
+					WithRejectDates(flags&0b0100 != 0),
+					WithRejectRepeats(flags&0b0010 != 0),
+					WithRejectSequences(flags&0b0001 != 0),
+				)
+				assert.Nil(t, err)
+
+				expected, pin := int64(0), make([]byte, length)
+				for n := 0; n < pow10[length]; n++ {
+					for idx, v := length-1, n; idx >= 0; idx, v = idx-1, v/10 {
+						pin[idx] = byte('0' + v%10)
+					}
+					if g.(*generator).weakness(pin) == nil {
+						expected++
+					}
+				}
+				assert.Equal(t, big.NewInt(expected), g.Entropy().Keyspace)
+			})
+		}
+	}
+}
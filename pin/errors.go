@@ -0,0 +1,16 @@
+package pin
+
+import "errors"
+
+var (
+	ErrBufferTooSmall      = errors.New("buffer is too small to hold the generated PIN")
+	ErrCommonPIN           = errors.New("PIN is one of the most commonly used PINs")
+	ErrDate                = errors.New("PIN looks like a date")
+	ErrEntropyTooLow       = errors.New("entropy of the PINs is lower than the minimum requested")
+	ErrInvalidLength       = errors.New("PIN length must be between 4 and 18 digits")
+	ErrMaxAttemptsExceeded = errors.New("failed to generate a PIN meeting the requirements")
+	ErrNotNumeric          = errors.New("PIN contains characters other than digits")
+	ErrRepeatedDigits      = errors.New("PIN is a repetition of the same digits")
+	ErrSequentialDigits    = errors.New("PIN is a run of ascending or descending digits")
+	ErrWrongLength         = errors.New("PIN has the wrong number of digits")
+)
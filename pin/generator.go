@@ -0,0 +1,139 @@
+package pin
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-passwords/entropy"
+	"github.com/jedib0t/go-passwords/rng"
+)
+
+var (
+	// maxGenerateAttempts is the number of PINs generated before giving up
+	// on finding one that is not weak.
+	maxGenerateAttempts = 1000
+
+	// pow10 holds the powers of 10 for all the supported lengths.
+	pow10 = func() []int {
+		rsp := []int{1}
+		for idx := 1; idx <= maxLength; idx++ {
+			rsp = append(rsp, rsp[idx-1]*10)
+		}
+		return rsp
+	}()
+)
+
+const (
+	minLength = 4
+	maxLength = 18
+
+	// maxDigitsPerDraw is the most digits drawn from a single random number,
+	// as 10^9 is the largest power of 10 below 2^32.
+	maxDigitsPerDraw = 9
+)
+
+type Generator interface {
+	// Entropy returns the exact number of distinct PINs that can be
+	// generated, and the bits of entropy it translates to.
+	Entropy() entropy.Stats
+	// Generate returns a randomly generated PIN.
+	Generate() (string, error)
+	// GenerateTo generates a PIN and writes it to the provided buffer.
+	// It returns the number of bytes written or an error.
+	GenerateTo(buf []byte) (int, error)
+	// Validate returns an error if the given PIN does not have the right
+	// number of digits, or is one the Generator would reject as weak.
+	Validate(pin string) error
+}
+
+type generator struct {
+	minEntropy      float64
+	numDigits       int
+	rejectCommon    bool
+	rejectDates     bool
+	rejectRepeats   bool
+	rejectSequences bool
+	rng             rng.Source
+}
+
+// NewGenerator returns a PIN generator that implements the Generator
+// interface.
+func NewGenerator(rules ...Rule) (Generator, error) {
+	g := &generator{}
+	for _, opt := range append(basicRules, rules...) {
+		opt(g)
+	}
+	return g.sanitize()
+}
+
+// Generate returns a randomly generated PIN.
+func (g *generator) Generate() (string, error) {
+	buf := make([]byte, g.numDigits)
+	n, err := g.GenerateTo(buf)
+	if err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+// GenerateTo generates a PIN and writes it to the provided buffer.
+// It returns the number of bytes written or an error.
+func (g *generator) GenerateTo(buf []byte) (int, error) {
+	if len(buf) < g.numDigits {
+		return 0, ErrBufferTooSmall
+	}
+
+	pin := buf[:g.numDigits]
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		if err := g.fillDigits(pin); err != nil {
+			return 0, err
+		}
+
+		// rejecting the weak PINs and trying again keeps the rest uniformly
+		// distributed
+		if g.weakness(pin) == nil {
+			return len(pin), nil
+		}
+	}
+	return 0, ErrMaxAttemptsExceeded
+}
+
+// fillDigits fills the PIN with random digits, drawn no more than
+// maxDigitsPerDraw at a time as IntN is only uniform for numbers below 2^32.
+func (g *generator) fillDigits(pin []byte) error {
+	for end := len(pin); end > 0; end -= maxDigitsPerDraw {
+		start := max(end-maxDigitsPerDraw, 0)
+		n, err := g.rng.IntN(pow10[end-start])
+		if err != nil {
+			return fmt.Errorf("failed to generate random number: %w", err)
+		}
+		for idx := end - 1; idx >= start; idx-- {
+			pin[idx] = byte('0' + n%10)
+			n /= 10
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the given PIN does not have the right number of
+// digits, or is one the Generator would reject as weak.
+func (g *generator) Validate(pin string) error {
+	if len(pin) != g.numDigits {
+		return fmt.Errorf("%w: found %d, need %d", ErrWrongLength, len(pin), g.numDigits)
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("%w: %q", ErrNotNumeric, r)
+		}
+	}
+	return g.weakness([]byte(pin))
+}
+
+func (g *generator) sanitize() (Generator, error) {
+	if g.numDigits < minLength || g.numDigits > maxLength {
+		return nil, ErrInvalidLength
+	}
+	if g.minEntropy > 0 && g.Entropy().Bits < g.minEntropy {
+		return nil, ErrEntropyTooLow
+	}
+	return g, nil
+}
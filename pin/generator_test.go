@@ -0,0 +1,120 @@
+package pin
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/jedib0t/go-passwords/rng"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	for _, length := range []int{4, 6, 8, 18} {
+		g, err := NewGenerator(WithLength(length))
+		assert.Nil(t, err)
+
+		for idx := 0; idx < 1000; idx++ {
+			pin, err := g.Generate()
+			assert.NoError(t, err)
+			assert.Len(t, pin, length)
+			assert.NoError(t, g.Validate(pin), pin)
+		}
+	}
+}
+
+func TestGenerator_Generate_RejectsWeakPINs(t *testing.T) {
+	// 4-digit PINs are small enough to see every one of them get generated
+	g, err := NewGenerator(
+		WithLength(4),
+		WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+	)
+	assert.Nil(t, err)
+
+	seen := make(map[string]bool)
+	for idx := 0; idx < 200000; idx++ {
+		pin, err := g.Generate()
+		assert.NoError(t, err)
+		seen[pin] = true
+	}
+	assert.Equal(t, g.Entropy().Keyspace.Int64(), int64(len(seen)))
+	for _, pin := range []string{"0000", "1234", "9876", "1225", "1987", "2580"} {
+		assert.False(t, seen[pin], pin)
+	}
+}
+
+func TestGenerator_Generate_LongPINs(t *testing.T) {
+	// PINs longer than 9 digits do not fit in a single 32-bit random number,
+	// and yet every digit must show up at every position
+	for _, length := range []int{10, 12, 18} {
+		g, err := NewGenerator(WithLength(length))
+		assert.Nil(t, err)
+
+		seen := make([]map[byte]bool, length)
+		for idx := range seen {
+			seen[idx] = make(map[byte]bool)
+		}
+		for idx := 0; idx < 1000; idx++ {
+			pin, err := g.Generate()
+			assert.NoError(t, err)
+			for pos := range pin {
+				seen[pos][pin[pos]] = true
+			}
+		}
+		for pos, digits := range seen {
+			assert.Len(t, digits, 10, "length %d, position %d", length, pos)
+		}
+	}
+}
+
+func TestGenerator_GenerateTo(t *testing.T) {
+	g, err := NewGenerator(WithLength(6))
+	assert.Nil(t, err)
+
+	buf := make([]byte, 8)
+	n, err := g.GenerateTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+	assert.NoError(t, g.Validate(string(buf[:n])))
+
+	n, err = g.GenerateTo(make([]byte, 5))
+	assert.Equal(t, ErrBufferTooSmall, err)
+	assert.Equal(t, 0, n)
+
+	t.Run("max attempts", func(t *testing.T) {
+		defer func(n int) { maxGenerateAttempts = n }(maxGenerateAttempts)
+		maxGenerateAttempts = 0
+
+		n, err := g.GenerateTo(buf)
+		assert.Equal(t, ErrMaxAttemptsExceeded, err)
+		assert.Equal(t, 0, n)
+	})
+}
+
+func TestGenerator_Validate(t *testing.T) {
+	g, err := NewGenerator(WithLength(4))
+	assert.Nil(t, err)
+
+	assert.NoError(t, g.Validate("4826"))
+	assert.EqualError(t, g.Validate("48261"), "PIN has the wrong number of digits: found 5, need 4")
+	assert.EqualError(t, g.Validate("48a6"), "PIN contains characters other than digits: 'a'")
+	assert.Equal(t, ErrRepeatedDigits, g.Validate("1111"))
+	assert.Equal(t, ErrSequentialDigits, g.Validate("3456"))
+	assert.Equal(t, ErrDate, g.Validate("0704"))
+	assert.Equal(t, ErrCommonPIN, g.Validate("2580"))
+
+	g, err = NewGenerator(WithLength(4), WithRejectDates(false))
+	assert.Nil(t, err)
+	assert.NoError(t, g.Validate("0704"))
+}
+
+func TestNewGenerator_Errors(t *testing.T) {
+	for _, length := range []int{0, 3, 19} {
+		g, err := NewGenerator(WithLength(length))
+		assert.Nil(t, g)
+		assert.Equal(t, ErrInvalidLength, err)
+	}
+
+	g, err := NewGenerator(WithLength(4), WithMinEntropy(14))
+	assert.Nil(t, g)
+	assert.Equal(t, ErrEntropyTooLow, err)
+}
@@ -0,0 +1,80 @@
+package pin
+
+import "github.com/jedib0t/go-passwords/rng"
+
+// Rule controls how the Generator generates PINs.
+type Rule func(g *generator)
+
+var (
+	basicRules = []Rule{
+		WithLength(6),
+		WithRandomSource(rng.Default()),
+		WithRejectCommon(true),
+		WithRejectDates(true),
+		WithRejectRepeats(true),
+		WithRejectSequences(true),
+	}
+)
+
+// WithLength sets the number of digits in the PIN; it has to be between 4 and
+// 18 (both inclusive).
+func WithLength(l int) Rule {
+	return func(g *generator) {
+		g.numDigits = l
+	}
+}
+
+// WithMinEntropy ensures the Generator is configured to generate PINs with at
+// least the given bits of entropy; NewGenerator returns ErrEntropyTooLow
+// otherwise.
+func WithMinEntropy(bits float64) Rule {
+	return func(g *generator) {
+		g.minEntropy = bits
+	}
+}
+
+// WithRandomSource sets the source of randomness used to generate PINs. A nil
+// Source resets it to the default buffered crypto/rand Source.
+func WithRandomSource(src rng.Source) Rule {
+	if src == nil {
+		src = rng.Default()
+	}
+
+	return func(g *generator) {
+		g.rng = src
+	}
+}
+
+// WithRejectCommon rejects the PINs found in the built-in list of the most
+// commonly used PINs (like "1234", "2580" or "696969").
+func WithRejectCommon(enabled bool) Rule {
+	return func(g *generator) {
+		g.rejectCommon = enabled
+	}
+}
+
+// WithRejectDates rejects the PINs that look like dates:
+// * 4 digits: MMDD, DDMM and YYYY (1900 to 2099)
+// * 6 digits: MMDDYY, DDMMYY and YYMMDD
+// * 8 digits: MMDDYYYY, DDMMYYYY and YYYYMMDD
+func WithRejectDates(enabled bool) Rule {
+	return func(g *generator) {
+		g.rejectDates = enabled
+	}
+}
+
+// WithRejectRepeats rejects the PINs made up of a repeated digit or block of
+// digits (like "0000", "1212" or "123123").
+func WithRejectRepeats(enabled bool) Rule {
+	return func(g *generator) {
+		g.rejectRepeats = enabled
+	}
+}
+
+// WithRejectSequences rejects the PINs made up of ascending or descending
+// digits, wrapping around from 9 to 0 (like "1234", "7890" or "9876").
+func WithRejectSequences(enabled bool) Rule {
+	return func(g *generator) {
+		g.rejectSequences = enabled
+	}
+}
@@ -0,0 +1,145 @@
+package pin
+
+import "strings"
+
+var (
+	// commonPINs holds the most commonly used PINs, as seen in leaked PIN and
+	// password lists; most of them are repeats, sequences, dates, patterns on
+	// the keypad or words spelled on it.
+	commonPINs = toSet(
+		// 4 digits
+		"0000", "0007", "0258", "0852", "1004", "1010", "1111", "1122",
+		"1212", "1234", "1313", "1357", "1379", "1470", "1590", "2000",
+		"2001", "2222", "2468", "2580", "3333", "3690", "4321", "4444",
+		"5555", "5683", "6666", "6969", "7410", "7531", "7777", "8520",
+		"8888", "9630", "9999",
+		// 6 digits
+		"000000", "102030", "111111", "112233", "121212", "123123",
+		"123321", "123456", "123654", "131313", "147258", "147852",
+		"159357", "159753", "252525", "258456", "456789", "520520",
+		"654321", "666666", "696969", "741852", "753159", "789456",
+		"852456", "963852", "987654",
+		// 8 digits
+		"11111111", "11223344", "12121212", "12341234", "12344321",
+		"12345678", "14725836", "14785236", "87654321",
+	)
+
+	// dateLayouts holds the layouts of the dates rejected for each length.
+	dateLayouts = map[int][]string{
+		4: {"MMDD", "DDMM", "YYYY"},
+		6: {"MMDDYY", "DDMMYY", "YYMMDD"},
+		8: {"MMDDYYYY", "DDMMYYYY", "YYYYMMDD"},
+	}
+	// daysInMonth holds the number of days in each month, with February
+	// having 29 days to include leap years.
+	daysInMonth = []int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+)
+
+const (
+	minYear = 1900
+	maxYear = 2099
+)
+
+// weakness returns the reason the given PIN is weak, or nil if it isn't.
+func (g *generator) weakness(pin []byte) error {
+	if g.rejectRepeats && isRepeated(pin) {
+		return ErrRepeatedDigits
+	}
+	if g.rejectSequences && isSequential(pin) {
+		return ErrSequentialDigits
+	}
+	if g.rejectDates && isDate(pin) {
+		return ErrDate
+	}
+	if g.rejectCommon && commonPINs[string(pin)] {
+		return ErrCommonPIN
+	}
+	return nil
+}
+
+// isRepeated returns true if the PIN is made up of a repeated block of
+// digits.
+func isRepeated(pin []byte) bool {
+	for blockLen := 1; blockLen <= len(pin)/2; blockLen++ {
+		if len(pin)%blockLen == 0 && hasPeriod(pin, blockLen) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPeriod returns true if every digit matches the one period digits before
+// it.
+func hasPeriod(pin []byte, period int) bool {
+	for idx := period; idx < len(pin); idx++ {
+		if pin[idx] != pin[idx-period] {
+			return false
+		}
+	}
+	return true
+}
+
+// isSequential returns true if every digit is one more (or one less) than the
+// one before it, wrapping around from 9 to 0.
+func isSequential(pin []byte) bool {
+	step := (pin[1] + 10 - pin[0]) % 10
+	if step != 1 && step != 9 {
+		return false
+	}
+	for idx := 2; idx < len(pin); idx++ {
+		if (pin[idx]+10-pin[idx-1])%10 != step {
+			return false
+		}
+	}
+	return true
+}
+
+// isDate returns true if the PIN matches any of the date layouts for its
+// length.
+func isDate(pin []byte) bool {
+	for _, layout := range dateLayouts[len(pin)] {
+		if matchesDateLayout(pin, layout) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDateLayout returns true if the PIN is a valid date in the given
+// layout.
+func matchesDateLayout(pin []byte, layout string) bool {
+	if idx := strings.Index(layout, "YYYY"); idx >= 0 {
+		if year := toNumber(pin[idx : idx+4]); year < minYear || year > maxYear {
+			return false
+		}
+	}
+	month := 0
+	if idx := strings.Index(layout, "MM"); idx >= 0 {
+		if month = toNumber(pin[idx : idx+2]); month < 1 || month > 12 {
+			return false
+		}
+	}
+	if idx := strings.Index(layout, "DD"); idx >= 0 {
+		if day := toNumber(pin[idx : idx+2]); day < 1 || day > daysInMonth[month] {
+			return false
+		}
+	}
+	return true
+}
+
+// toNumber returns the number the given digits make up.
+func toNumber(digits []byte) int {
+	rsp := 0
+	for _, d := range digits {
+		rsp = rsp*10 + int(d-'0')
+	}
+	return rsp
+}
+
+func toSet(values ...string) map[string]bool {
+	rsp := make(map[string]bool, len(values))
+	for _, value := range values {
+		rsp[value] = true
+	}
+	return rsp
+}
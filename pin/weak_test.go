@@ -0,0 +1,72 @@
+package pin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRepeated(t *testing.T) {
+	for pin, expected := range map[string]bool{
+		"0000":   true,
+		"1212":   true,
+		"123123": true,
+		"100100": true,
+		"112112": true,
+		"1211":   false,
+		"123124": false,
+		"1000":   false,
+	} {
+		assert.Equal(t, expected, isRepeated([]byte(pin)), pin)
+	}
+}
+
+func TestIsSequential(t *testing.T) {
+	for pin, expected := range map[string]bool{
+		"1234":   true,
+		"9876":   true,
+		"7890":   true,
+		"0987":   true,
+		"345678": true,
+		"1235":   false,
+		"1357":   false,
+		"1221":   false,
+	} {
+		assert.Equal(t, expected, isSequential([]byte(pin)), pin)
+	}
+}
+
+func TestIsDate(t *testing.T) {
+	for pin, expected := range map[string]bool{
+		"1225":     true,  // MMDD
+		"2512":     true,  // DDMM
+		"0229":     true,  // MMDD, leap day
+		"1987":     true,  // YYYY
+		"2099":     true,  // YYYY
+		"0230":     false, // no 30th of February
+		"1899":     false,
+		"3113":     false,
+		"122599":   true,  // MMDDYY
+		"251299":   true,  // DDMMYY
+		"991225":   true,  // YYMMDD
+		"991325":   false, // no 13th month
+		"12251999": true,  // MMDDYYYY
+		"25121999": true,  // DDMMYYYY
+		"19991225": true,  // YYYYMMDD
+		"25122199": false, // year out of range
+		"12345":    false, // no layouts for 5 digits
+	} {
+		assert.Equal(t, expected, isDate([]byte(pin)), pin)
+	}
+}
+
+func TestDatesInLayout(t *testing.T) {
+	assert.Len(t, datesInLayout("YYYY"), 200)
+	assert.Len(t, datesInLayout("MMDD"), 366)
+	assert.Len(t, datesInLayout("DDMMYY"), 36600)
+	assert.Equal(t, "0101", datesInLayout("DDMM")[0])
+	assert.Equal(t, "19000101", datesInLayout("YYYYMMDD")[0])
+	for _, date := range datesInLayout("MMDDYYYY") {
+		assert.True(t, isDate([]byte(date)), date)
+	}
+}
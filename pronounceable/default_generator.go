@@ -0,0 +1,18 @@
+package pronounceable
+
+var (
+	defaultGenerator, _ = NewGenerator(
+		WithCapitalization(CapitalizeFirst),
+		WithLength(12),
+		WithNumDigits(2),
+	)
+)
+
+// Generate generates and returns a password that follows the following rules:
+// * uses the "cv" and "cvc" syllable templates
+// * ensures the password is 12 characters long
+// * capitalizes the first letter
+// * inserts two digits at random positions
+func Generate() (string, error) {
+	return defaultGenerator.Generate()
+}
@@ -0,0 +1,122 @@
+package pronounceable
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"unicode"
+
+	"github.com/jedib0t/go-passwords/entropy"
+)
+
+// Entropy returns the exact number of distinct passwords the Generator can
+// generate, and the bits of entropy it translates to.
+func (g *generator) Entropy() entropy.Stats {
+	numExtras := g.numDigits + g.numSymbols
+	keyspace := g.lettersKeyspace(g.numChars - numExtras)
+
+	// the positions of the digits and symbols among all the characters, and
+	// then the positions of the digits among them
+	term := new(big.Int)
+	keyspace.Mul(keyspace, term.Binomial(int64(g.numChars), int64(numExtras)))
+	keyspace.Mul(keyspace, term.Binomial(int64(numExtras), int64(g.numDigits)))
+	keyspace.Mul(keyspace, term.Exp(big.NewInt(int64(len(g.digits))), big.NewInt(int64(g.numDigits)), nil))
+	keyspace.Mul(keyspace, term.Exp(big.NewInt(int64(len(g.symbols))), big.NewInt(int64(g.numSymbols)), nil))
+	return entropy.New(keyspace)
+}
+
+// syllableState is a position within a syllable template.
+type syllableState struct {
+	template, index int
+}
+
+// lettersKeyspace returns the number of distinct strings of the given length
+// that can be built by joining the syllables.
+//
+// Different sequences of templates can result in the same string (for ex.,
+// "cv"+"cvc" and "cvc"+"vc"), so the strings are counted by walking all the
+// template positions a string can end at in parallel (a subset construction),
+// rather than by counting the sequences of templates.
+func (g *generator) lettersKeyspace(length int) *big.Int {
+	weights := map[byte]*big.Int{
+		'c': big.NewInt(g.numVariants(g.consonants)),
+		'v': big.NewInt(g.numVariants(g.vowels)),
+	}
+
+	// ways maps a set of template positions to the number of strings that
+	// can end in exactly that set
+	start := g.syllableStarts()
+	ways := map[string]*big.Int{fmt.Sprint(start): big.NewInt(1)}
+	states := map[string][]syllableState{fmt.Sprint(start): start}
+	for idx := 0; idx < length; idx++ {
+		nextWays, nextStates := make(map[string]*big.Int), make(map[string][]syllableState)
+		for key, count := range ways {
+			for kind, weight := range weights {
+				next := g.nextSyllableStates(states[key], kind)
+				if len(next) == 0 {
+					continue
+				}
+				nextKey := fmt.Sprint(next)
+				if nextWays[nextKey] == nil {
+					nextWays[nextKey], nextStates[nextKey] = new(big.Int), next
+				}
+				nextWays[nextKey].Add(nextWays[nextKey], new(big.Int).Mul(count, weight))
+			}
+		}
+		ways, states = nextWays, nextStates
+	}
+
+	// the last syllable can be cut short, so every set is a valid end
+	keyspace := new(big.Int)
+	for _, count := range ways {
+		keyspace.Add(keyspace, count)
+	}
+	return keyspace
+}
+
+// nextSyllableStates returns the template positions reachable from the given
+// ones by a letter of the given kind.
+func (g *generator) nextSyllableStates(states []syllableState, kind byte) []syllableState {
+	var next []syllableState
+	for _, state := range states {
+		template := g.templates[state.template]
+		if template[state.index] != kind {
+			continue
+		}
+		if state.index+1 < len(template) {
+			next = append(next, syllableState{template: state.template, index: state.index + 1})
+		} else {
+			next = append(next, g.syllableStarts()...)
+		}
+	}
+	slices.SortFunc(next, func(a, b syllableState) int {
+		if a.template != b.template {
+			return a.template - b.template
+		}
+		return a.index - b.index
+	})
+	return slices.Compact(next)
+}
+
+// syllableStarts returns the positions at the start of every template.
+func (g *generator) syllableStarts() []syllableState {
+	states := make([]syllableState, len(g.templates))
+	for idx := range g.templates {
+		states[idx] = syllableState{template: idx}
+	}
+	return states
+}
+
+// numVariants returns the number of distinct letters that can be generated
+// from the given ones.
+func (g *generator) numVariants(letters []rune) int64 {
+	numVariants := int64(len(letters))
+	if g.capitalization == CapitalizeRandom {
+		for _, r := range letters {
+			if unicode.ToUpper(r) != r {
+				numVariants++
+			}
+		}
+	}
+	return numVariants
+}
@@ -0,0 +1,62 @@
+package pronounceable
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/jedib0t/go-passwords/rng"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Entropy(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		g, err := NewGenerator()
+		assert.Nil(t, err)
+
+		stats := g.Entropy()
+		assert.True(t, stats.Bits > 40, stats.String())
+	})
+
+	t.Run("single template", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithLength(5),
+			WithNumDigits(1),
+			WithNumSymbols(1),
+			WithSymbolCharset("!@"),
+			WithSyllableTemplates("cv"),
+		)
+		assert.Nil(t, err)
+
+		// cvc (16*5*16) * positions (5*4) * digits (10) * symbols (2)
+		assert.Equal(t, big.NewInt(16*5*16*5*4*10*2), g.Entropy().Keyspace)
+	})
+
+	// the keyspace must match the number of distinct passwords generated when
+	// it is small enough to exhaust, even when templates generate the same
+	// strings in different ways
+	for name, rules := range map[string][]Rule{
+		"ambiguous templates": {WithSyllableTemplates("cv", "cvc", "v", "vc")},
+		"capitalize first":    {WithSyllableTemplates("cv", "vc"), WithCapitalization(CapitalizeFirst)},
+		"capitalize random":   {WithSyllableTemplates("cvc", "cv"), WithCapitalization(CapitalizeRandom)},
+		"digits and symbols":  {WithSyllableTemplates("cvv", "c"), WithNumDigits(1), WithNumSymbols(1), WithSymbolCharset("!")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewGenerator(append(rules,
+				WithConsonants("bd"),
+				WithLength(5),
+				WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+				WithVowels("ae"),
+			)...)
+			assert.Nil(t, err)
+
+			seen := make(map[string]bool)
+			for idx := 0; idx < 200000; idx++ {
+				password, err := g.Generate()
+				assert.NoError(t, err)
+				seen[password] = true
+			}
+			assert.Equal(t, int64(len(seen)), g.Entropy().Keyspace.Int64())
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package pronounceable
+
+import "errors"
+
+var (
+	ErrBufferTooSmall          = errors.New("buffer is too small to hold the generated password")
+	ErrCharsetsOverlap         = errors.New("consonants, vowels, digits and symbols cannot share characters")
+	ErrEntropyTooLow           = errors.New("entropy of the passwords is lower than the minimum requested")
+	ErrInvalidCapitalization   = errors.New("unknown capitalization")
+	ErrInvalidSyllableTemplate = errors.New("syllable templates can only contain 'c' (consonant) and 'v' (vowel)")
+	ErrNoConsonants            = errors.New("found no consonants to use in the syllable templates")
+	ErrNoSymbolsInCharset      = errors.New("found no symbols to use in charset")
+	ErrNoSyllableTemplates     = errors.New("found no syllable templates to use")
+	ErrNoVowels                = errors.New("found no vowels to use in the syllable templates")
+	ErrRequirementsNotMet      = errors.New("number of digits+symbols requested leaves no room for syllables")
+	ErrZeroLenPassword         = errors.New("cannot generate passwords with 0 length")
+)
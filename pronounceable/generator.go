@@ -0,0 +1,335 @@
+package pronounceable
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/entropy"
+	"github.com/jedib0t/go-passwords/rng"
+)
+
+var (
+	// Consonants are the default consonants used in the syllables; it leaves
+	// out the ones that are easily confused when read out (c, q, w, x and y).
+	Consonants charset.Charset = "bdfghjklmnprstvz"
+	// Vowels are the default vowels used in the syllables.
+	Vowels charset.Charset = "aeiou"
+
+	// storagePoolMinSize is the minimum number of objects to keep in the pool
+	// to support enough parallelism.
+	storagePoolMinSize = 25
+)
+
+// Capitalization controls the letters that are capitalized in a password.
+type Capitalization int
+
+// Supported Capitalization values.
+const (
+	// CapitalizeNone keeps all the letters in lower case.
+	CapitalizeNone Capitalization = iota
+	// CapitalizeFirst capitalizes the first letter.
+	CapitalizeFirst
+	// CapitalizeRandom capitalizes each letter with a 50% chance.
+	CapitalizeRandom
+)
+
+type Generator interface {
+	// Entropy returns the exact number of distinct passwords that can be
+	// generated, and the bits of entropy it translates to.
+	Entropy() entropy.Stats
+	// Generate returns a randomly generated password.
+	Generate() (string, error)
+	// GenerateTo generates a password and writes it to the provided buffer.
+	// It returns the number of bytes written or an error.
+	GenerateTo(buf []byte) (int, error)
+}
+
+type generator struct {
+	capitalization Capitalization
+	consonants     []rune
+	digits         []rune
+	minEntropy     float64
+	numChars       int
+	numDigits      int
+	numSymbols     int
+	pool           *sync.Pool
+	rng            rng.Source
+	symbols        []rune
+	templates      []string
+	vowels         []rune
+}
+
+// workspace holds the scratch buffers used to generate a single password.
+type workspace struct {
+	password  []rune
+	letters   []rune
+	extras    []rune
+	positions []int
+	isExtra   []bool
+	swap      func(i, j int)
+}
+
+func newWorkspace(numChars int) *workspace {
+	w := &workspace{
+		password:  make([]rune, numChars),
+		letters:   make([]rune, numChars),
+		extras:    make([]rune, numChars),
+		positions: make([]int, numChars),
+		isExtra:   make([]bool, numChars),
+	}
+	w.swap = func(i, j int) {
+		w.extras[i], w.extras[j] = w.extras[j], w.extras[i]
+	}
+	return w
+}
+
+// NewGenerator returns a pronounceable password generator that implements the
+// Generator interface.
+func NewGenerator(rules ...Rule) (Generator, error) {
+	g := &generator{digits: []rune(charset.Numbers)}
+	for _, opt := range append(basicRules, rules...) {
+		opt(g)
+	}
+
+	// create a storage pool with enough objects to support enough parallelism
+	g.pool = &sync.Pool{
+		New: func() any {
+			return newWorkspace(g.numChars)
+		},
+	}
+	for idx := 0; idx < storagePoolMinSize; idx++ {
+		g.pool.Put(newWorkspace(g.numChars))
+	}
+
+	return g.sanitize()
+}
+
+// Generate returns a randomly generated password.
+func (g *generator) Generate() (string, error) {
+	buf := make([]byte, g.numChars*utf8.UTFMax)
+	n, err := g.GenerateTo(buf)
+	if err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+// GenerateTo generates a password and writes it to the provided buffer.
+// It returns the number of bytes written or an error.
+func (g *generator) GenerateTo(buf []byte) (int, error) {
+	// use the pool to get a workspace for working on
+	ws := g.pool.Get().(*workspace)
+	defer g.pool.Put(ws)
+
+	// build the syllables first
+	letters := ws.letters[:g.numChars-g.numDigits-g.numSymbols]
+	if err := g.fillLetters(letters); err != nil {
+		return 0, err
+	}
+
+	// followed by the digits and symbols
+	if err := g.fillExtras(ws); err != nil {
+		return 0, err
+	}
+
+	// and merge them while keeping the syllables in order
+	password, letterIdx, extraIdx := ws.password[:g.numChars], 0, 0
+	for idx := range password {
+		if ws.isExtra[idx] {
+			password[idx] = ws.extras[extraIdx]
+			extraIdx++
+		} else {
+			password[idx] = letters[letterIdx]
+			letterIdx++
+		}
+	}
+
+	// write to the buffer
+	return g.writeToBuf(password, buf)
+}
+
+// fillLetters fills the given buffer with syllables built from randomly picked
+// templates, cutting the last one short if needed.
+func (g *generator) fillLetters(letters []rune) error {
+	for idx := 0; idx < len(letters); {
+		templateIdx, err := g.randomIndex(len(g.templates))
+		if err != nil {
+			return err
+		}
+
+		template := g.templates[templateIdx]
+		for tIdx := 0; tIdx < len(template) && idx < len(letters); tIdx++ {
+			runes := g.consonants
+			if template[tIdx] == 'v' {
+				runes = g.vowels
+			}
+			n, err := g.randomIndex(len(runes))
+			if err != nil {
+				return err
+			}
+
+			if letters[idx], err = g.capitalize(idx, runes[n]); err != nil {
+				return err
+			}
+			idx++
+		}
+	}
+	return nil
+}
+
+// capitalize returns the letter at the given index in the password capitalized
+// as needed.
+func (g *generator) capitalize(idx int, r rune) (rune, error) {
+	switch g.capitalization {
+	case CapitalizeFirst:
+		if idx == 0 {
+			return unicode.ToUpper(r), nil
+		}
+	case CapitalizeRandom:
+		n, err := g.rng.IntN(2)
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate random number: %w", err)
+		}
+		if n == 1 {
+			return unicode.ToUpper(r), nil
+		}
+	}
+	return r, nil
+}
+
+// fillExtras picks the digits and symbols to insert in the password, along
+// with the positions to insert them at.
+func (g *generator) fillExtras(ws *workspace) error {
+	numExtras := g.numDigits + g.numSymbols
+	clear(ws.isExtra)
+	if numExtras == 0 {
+		return nil
+	}
+
+	// pick the positions using a partial Fisher-Yates shuffle
+	positions := ws.positions[:g.numChars]
+	for idx := range positions {
+		positions[idx] = idx
+	}
+	for idx := 0; idx < numExtras; idx++ {
+		n, err := g.randomIndex(len(positions) - idx)
+		if err != nil {
+			return err
+		}
+		positions[idx], positions[idx+n] = positions[idx+n], positions[idx]
+		ws.isExtra[positions[idx]] = true
+	}
+
+	// pick the digits and symbols, and mix them up
+	extras := ws.extras[:numExtras]
+	for idx := range extras {
+		runes := g.digits
+		if idx >= g.numDigits {
+			runes = g.symbols
+		}
+		n, err := g.randomIndex(len(runes))
+		if err != nil {
+			return err
+		}
+		extras[idx] = runes[n]
+	}
+	if err := g.rng.Shuffle(numExtras, ws.swap); err != nil {
+		return fmt.Errorf("failed to shuffle digits and symbols: %w", err)
+	}
+	return nil
+}
+
+// randomIndex returns a random number in [0, n).
+func (g *generator) randomIndex(n int) (int, error) {
+	if n == 1 {
+		return 0, nil
+	}
+	rsp, err := g.rng.IntN(n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate random number: %w", err)
+	}
+	return rsp, nil
+}
+
+func (g *generator) writeToBuf(password []rune, buf []byte) (int, error) {
+	offset := 0
+	for _, r := range password {
+		if offset+utf8.RuneLen(r) > len(buf) {
+			return 0, ErrBufferTooSmall
+		}
+		offset += utf8.EncodeRune(buf[offset:], r)
+	}
+	return offset, nil
+}
+
+func (g *generator) sanitize() (Generator, error) {
+	// validate the inputs
+	if g.numChars <= 0 {
+		return nil, ErrZeroLenPassword
+	}
+	if g.numDigits+g.numSymbols >= g.numChars {
+		return nil, ErrRequirementsNotMet
+	}
+	if g.capitalization < CapitalizeNone || g.capitalization > CapitalizeRandom {
+		return nil, ErrInvalidCapitalization
+	}
+	if err := g.sanitizeTemplates(); err != nil {
+		return nil, err
+	}
+	if g.numSymbols > 0 && len(g.symbols) == 0 {
+		return nil, ErrNoSymbolsInCharset
+	}
+	if g.charsetsOverlap() {
+		return nil, ErrCharsetsOverlap
+	}
+	if g.minEntropy > 0 && g.Entropy().Bits < g.minEntropy {
+		return nil, ErrEntropyTooLow
+	}
+	return g, nil
+}
+
+// sanitizeTemplates ensures there are valid templates to build syllables
+// from, along with the letters they need.
+func (g *generator) sanitizeTemplates() error {
+	if len(g.templates) == 0 {
+		return ErrNoSyllableTemplates
+	}
+	for _, template := range g.templates {
+		if template == "" || strings.Trim(template, "cv") != "" {
+			return fmt.Errorf("%w: %q", ErrInvalidSyllableTemplate, template)
+		}
+		if len(g.consonants) == 0 && strings.ContainsRune(template, 'c') {
+			return ErrNoConsonants
+		}
+		if len(g.vowels) == 0 && strings.ContainsRune(template, 'v') {
+			return ErrNoVowels
+		}
+	}
+	return nil
+}
+
+// charsetsOverlap returns true if a character can be found in more than one
+// of the consonants, vowels, digits and symbols, or if capitalizing a letter
+// can turn it into another one.
+func (g *generator) charsetsOverlap() bool {
+	seen := make(map[rune]bool)
+	for _, runes := range [][]rune{g.consonants, g.vowels, g.digits, g.symbols} {
+		for _, r := range runes {
+			variants := []rune{r}
+			if upper := unicode.ToUpper(r); upper != r && g.capitalization != CapitalizeNone {
+				variants = append(variants, upper)
+			}
+			for _, v := range variants {
+				if seen[v] {
+					return true
+				}
+				seen[v] = true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package pronounceable
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/rng"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	g, err := NewGenerator(
+		WithLength(12),
+		WithSyllableTemplates("cv"),
+	)
+	assert.Nil(t, err)
+
+	for idx := 0; idx < 100; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		assert.Len(t, password, 12)
+		// consonants and vowels must alternate
+		for pos, r := range password {
+			if pos%2 == 0 {
+				assert.True(t, Consonants.Contains(r), password)
+			} else {
+				assert.True(t, Vowels.Contains(r), password)
+			}
+		}
+	}
+}
+
+func TestGenerator_Generate_WithCapitalization(t *testing.T) {
+	t.Run("first", func(t *testing.T) {
+		g, err := NewGenerator(WithCapitalization(CapitalizeFirst))
+		assert.Nil(t, err)
+
+		for idx := 0; idx < 100; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			assert.True(t, unicode.IsUpper(rune(password[0])), password)
+			assert.Equal(t, strings.ToLower(password[1:]), password[1:])
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		g, err := NewGenerator(
+			WithCapitalization(CapitalizeRandom),
+			WithRandomSource(rng.NewSource(rand.New(rand.NewSource(0)))),
+		)
+		assert.Nil(t, err)
+
+		numUpper, numLower := 0, 0
+		for idx := 0; idx < 100; idx++ {
+			password, err := g.Generate()
+			assert.NoError(t, err)
+			for _, r := range password {
+				if unicode.IsUpper(r) {
+					numUpper++
+				} else {
+					numLower++
+				}
+			}
+		}
+		assert.InEpsilon(t, numLower, numUpper, 0.1)
+	})
+}
+
+func TestGenerator_Generate_WithDigitsAndSymbols(t *testing.T) {
+	g, err := NewGenerator(
+		WithLength(10),
+		WithNumDigits(2),
+		WithNumSymbols(1),
+		WithSymbolCharset("!@#"),
+		WithSyllableTemplates("cvc"),
+	)
+	assert.Nil(t, err)
+
+	for idx := 0; idx < 100; idx++ {
+		password, err := g.Generate()
+		assert.NoError(t, err)
+		assert.Len(t, password, 10)
+
+		var letters strings.Builder
+		numDigits, numSymbols := 0, 0
+		for _, r := range password {
+			switch {
+			case charset.Numbers.Contains(r):
+				numDigits++
+			case charset.Charset("!@#").Contains(r):
+				numSymbols++
+			default:
+				letters.WriteRune(r)
+			}
+		}
+		assert.Equal(t, 2, numDigits, password)
+		assert.Equal(t, 1, numSymbols, password)
+		// the syllables are kept in order around the digits and symbols
+		for pos, r := range letters.String() {
+			assert.Equal(t, pos%3 != 1, Consonants.Contains(r), password)
+		}
+	}
+}
+
+func TestGenerator_GenerateTo(t *testing.T) {
+	g, err := NewGenerator(WithLength(8), WithNumDigits(1))
+	assert.Nil(t, err)
+
+	buf := make([]byte, 8)
+	n, err := g.GenerateTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, n)
+
+	n, err = g.GenerateTo(make([]byte, 7))
+	assert.Equal(t, ErrBufferTooSmall, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestGenerator_Generate_Unicode(t *testing.T) {
+	g, err := NewGenerator(
+		WithCapitalization(CapitalizeFirst),
+		WithConsonants("бвгд"),
+		WithLength(6),
+		WithVowels("аеиоу"),
+	)
+	assert.Nil(t, err)
+
+	password, err := g.Generate()
+	assert.NoError(t, err)
+	assert.Len(t, []rune(password), 6)
+	assert.True(t, unicode.IsUpper([]rune(password)[0]), password)
+}
+
+func TestNewGenerator_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rules []Rule
+		err   error
+	}{
+		"zero length":        {[]Rule{WithLength(0)}, ErrZeroLenPassword},
+		"no room":            {[]Rule{WithLength(4), WithNumDigits(2), WithNumSymbols(2)}, ErrRequirementsNotMet},
+		"bad capitalization": {[]Rule{WithCapitalization(Capitalization(42))}, ErrInvalidCapitalization},
+		"no templates":       {[]Rule{WithSyllableTemplates()}, ErrNoSyllableTemplates},
+		"empty template":     {[]Rule{WithSyllableTemplates("cv", "")}, ErrInvalidSyllableTemplate},
+		"invalid template":   {[]Rule{WithSyllableTemplates("cvx")}, ErrInvalidSyllableTemplate},
+		"no consonants":      {[]Rule{WithConsonants("")}, ErrNoConsonants},
+		"no vowels":          {[]Rule{WithVowels("")}, ErrNoVowels},
+		"no symbols":         {[]Rule{WithNumSymbols(1), WithSymbolCharset("")}, ErrNoSymbolsInCharset},
+		"overlap":            {[]Rule{WithConsonants("bcd"), WithVowels("aeb")}, ErrCharsetsOverlap},
+		"overlap digits":     {[]Rule{WithVowels("ae1")}, ErrCharsetsOverlap},
+		"overlap upper":      {[]Rule{WithConsonants("bB"), WithCapitalization(CapitalizeFirst)}, ErrCharsetsOverlap},
+		"entropy too low":    {[]Rule{WithLength(4), WithMinEntropy(64)}, ErrEntropyTooLow},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewGenerator(tc.rules...)
+			assert.Nil(t, g)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	// upper-case consonants are fine when nothing is capitalized
+	_, err := NewGenerator(WithConsonants("bB"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,114 @@
+package pronounceable
+
+import (
+	"github.com/jedib0t/go-passwords/charset"
+	"github.com/jedib0t/go-passwords/rng"
+)
+
+// Rule controls how the Generator generates passwords.
+type Rule func(g *generator)
+
+var (
+	basicRules = []Rule{
+		WithCapitalization(CapitalizeNone),
+		WithConsonants(Consonants),
+		WithLength(12),
+		WithNumDigits(0),
+		WithNumSymbols(0),
+		WithRandomSource(rng.Default()),
+		WithSymbolCharset(charset.Symbols),
+		WithSyllableTemplates("cv", "cvc"),
+		WithVowels(Vowels),
+	}
+)
+
+// WithCapitalization sets the letters to capitalize in the password.
+func WithCapitalization(c Capitalization) Rule {
+	return func(g *generator) {
+		g.capitalization = c
+	}
+}
+
+// WithConsonants sets the consonants used for the 'c' in syllable templates.
+func WithConsonants(c charset.Charset) Rule {
+	return func(g *generator) {
+		g.consonants = []rune(c.WithoutDuplicates())
+	}
+}
+
+// WithLength sets the length of the generated password, including the digits
+// and symbols in it.
+func WithLength(l int) Rule {
+	return func(g *generator) {
+		g.numChars = l
+	}
+}
+
+// WithMinEntropy ensures the Generator is configured to generate passwords
+// with at least the given bits of entropy; NewGenerator returns
+// ErrEntropyTooLow otherwise.
+func WithMinEntropy(bits float64) Rule {
+	return func(g *generator) {
+		g.minEntropy = bits
+	}
+}
+
+// WithNumDigits sets the number of digits inserted at random positions in the
+// password.
+func WithNumDigits(n int) Rule {
+	if n < 0 {
+		n = 0
+	}
+
+	return func(g *generator) {
+		g.numDigits = n
+	}
+}
+
+// WithNumSymbols sets the number of symbols inserted at random positions in
+// the password.
+func WithNumSymbols(n int) Rule {
+	if n < 0 {
+		n = 0
+	}
+
+	return func(g *generator) {
+		g.numSymbols = n
+	}
+}
+
+// WithRandomSource sets the source of randomness used to generate passwords.
+// A nil Source resets it to the default buffered crypto/rand Source.
+func WithRandomSource(src rng.Source) Rule {
+	if src == nil {
+		src = rng.Default()
+	}
+
+	return func(g *generator) {
+		g.rng = src
+	}
+}
+
+// WithSymbolCharset sets the symbols that can be inserted in the password.
+func WithSymbolCharset(c charset.Charset) Rule {
+	return func(g *generator) {
+		g.symbols = []rune(c.WithoutDuplicates())
+	}
+}
+
+// WithSyllableTemplates sets the templates the syllables are built from, with
+// a 'c' for a consonant and a 'v' for a vowel; for ex., "cv" and "cvc" result
+// in syllables like "ba" and "kot". Syllables are picked at random until the
+// password is long enough, and the last one is cut short if needed.
+func WithSyllableTemplates(templates ...string) Rule {
+	return func(g *generator) {
+		g.templates = templates
+	}
+}
+
+// WithVowels sets the vowels used for the 'v' in syllable templates.
+func WithVowels(c charset.Charset) Rule {
+	return func(g *generator) {
+		g.vowels = []rune(c.WithoutDuplicates())
+	}
+}
@@ -1,8 +1,7 @@
 package rng
 
 import (
-	"crypto/rand"
-	"sync"
+	"io"
 )
 
 const (
@@ -11,41 +10,37 @@ const (
 	bufferSize = 1024
 )
 
-var (
-	// randBuffer holds buffered random bytes
-	randBuffer [bufferSize]byte
-	// randPos tracks the current position in the buffer
-	randPos int
-	// randMutex protects access to the buffer
-	randMutex sync.Mutex
-)
-
-// readBytesBuffered reads the requested number of bytes from the buffered crypto/rand.
-// It automatically refills the buffer when needed.
-func readBytesBuffered(b []byte) error {
-	needed := len(b)
-
-	// For large requests, skip the buffer and read directly
-	if needed > bufferSize/2 {
-		_, err := rand.Read(b)
-		return err
-	}
-
-	randMutex.Lock()
-	defer randMutex.Unlock()
-
-	available := bufferSize - randPos
-	if available < needed {
-		// Not enough bytes in buffer, refill it
-		if _, err := rand.Read(randBuffer[:]); err != nil {
-			return err
+// read reads the requested number of bytes from the buffered reader. It
+// automatically refills the buffer when needed.
+func (s *source) read(b []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for len(b) > 0 {
+		if s.pos >= s.end {
+			// Not enough bytes in buffer, refill it
+			n, err := io.ReadAtLeast(s.reader, s.buf[:], 1)
+			if err != nil {
+				return err
+			}
+			s.pos, s.end = 0, n
 		}
-		randPos = 0
+
+		// Copy bytes from buffer
+		n := copy(b, s.buf[s.pos:s.end])
+		s.pos += n
+		b = b[n:]
 	}
+	return nil
+}
 
-	// Copy bytes from buffer
-	copy(b, randBuffer[randPos:randPos+needed])
-	randPos += needed
+// readDirect reads the requested number of bytes straight from the reader,
+// skipping the buffer. Only Read uses it for large requests, as handing the
+// (stack) buffers of the other methods to the reader moves them to the heap.
+func (s *source) readDirect(b []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	return nil
+	_, err := io.ReadFull(s.reader, b)
+	return err
 }
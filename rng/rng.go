@@ -6,6 +6,38 @@ import (
 
 // IntN returns a random integer in [0, n) using crypto/rand.
 func IntN(n int) (int, error) {
+	return defaultSource.IntN(n)
+}
+
+// IntNs returns a slice of random integers in [0, n) using crypto/rand.
+// It uses batching to reduce mutex contention and stack-allocated buffers for
+// small requests to minimize heap allocations.
+func IntNs(n int, count int) ([]int, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	res := make([]int, count)
+	if err := FillIntNs(res, n); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// FillIntNs fills the provided slice with random integers in [0, n) using
+// crypto/rand.
+func FillIntNs(buf []int, n int) error {
+	return defaultSource.FillIntNs(buf, n)
+}
+
+// Shuffle shuffles the slice using Fisher-Yates algorithm with crypto/rand.
+func Shuffle[T any](slice []T) error {
+	return defaultSource.Shuffle(len(slice), func(i, j int) {
+		slice[i], slice[j] = slice[j], slice[i]
+	})
+}
+
+// IntN returns a random integer in [0, n).
+func (s *source) IntN(n int) (int, error) {
 	if n <= 1 {
 		return 0, ErrInvalidN
 	}
@@ -13,7 +45,7 @@ func IntN(n int) (int, error) {
 	// For small n, use modulo directly as bias is negligible.
 	if n <= 256 {
 		var b [1]byte
-		if err := readBytesBuffered(b[:]); err != nil {
+		if err := s.read(b[:]); err != nil {
 			return 0, err
 		}
 		return int(b[0]) % n, nil
@@ -23,7 +55,7 @@ func IntN(n int) (int, error) {
 	max := uint32((uint64(1) << 32) / uint64(n) * uint64(n))
 	if max == 0 {
 		var b [4]byte
-		if err := readBytesBuffered(b[:]); err != nil {
+		if err := s.read(b[:]); err != nil {
 			return 0, err
 		}
 		return int(binary.BigEndian.Uint32(b[:])) % n, nil
@@ -31,7 +63,7 @@ func IntN(n int) (int, error) {
 
 	var b [4]byte
 	for {
-		if err := readBytesBuffered(b[:]); err != nil {
+		if err := s.read(b[:]); err != nil {
 			return 0, err
 		}
 		val := binary.BigEndian.Uint32(b[:])
@@ -41,24 +73,10 @@ func IntN(n int) (int, error) {
 	}
 }
 
-// IntNs returns a slice of random integers in [0, n) using crypto/rand.
-// It uses batching to reduce mutex contention and stack-allocated buffers for
-// small requests to minimize heap allocations.
-func IntNs(n int, count int) ([]int, error) {
-	if count <= 0 {
-		return nil, nil
-	}
-	res := make([]int, count)
-	if err := FillIntNs(res, n); err != nil {
-		return nil, err
-	}
-	return res, nil
-}
-
 // FillIntNs fills the provided slice with random integers in [0, n).
 // It uses batching to reduce mutex contention and stack-allocated buffers for
 // small requests to minimize additional heap allocations.
-func FillIntNs(buf []int, n int) error {
+func (s *source) FillIntNs(buf []int, n int) error {
 	if n <= 1 {
 		return ErrInvalidN
 	}
@@ -78,7 +96,7 @@ func FillIntNs(buf []int, n int) error {
 			b = b[:count]
 		}
 
-		if err := readBytesBuffered(b); err != nil {
+		if err := s.read(b); err != nil {
 			return err
 		}
 		for i := 0; i < count; i++ {
@@ -99,7 +117,7 @@ func FillIntNs(buf []int, n int) error {
 			b = b[:count*4]
 		}
 
-		if err := readBytesBuffered(b); err != nil {
+		if err := s.read(b); err != nil {
 			return err
 		}
 		for i := 0; i < count; i++ {
@@ -112,7 +130,7 @@ func FillIntNs(buf []int, n int) error {
 	var b [4]byte
 	for i := 0; i < count; i++ {
 		for {
-			if err := readBytesBuffered(b[:]); err != nil {
+			if err := s.read(b[:]); err != nil {
 				return err
 			}
 			val := binary.BigEndian.Uint32(b[:])
@@ -125,11 +143,10 @@ func FillIntNs(buf []int, n int) error {
 	return nil
 }
 
-// Shuffle shuffles the slice using Fisher-Yates algorithm with crypto/rand.
-// For slices smaller than 256, it uses a batch of random bytes to avoid
-// repeated RNG calls and mutex overhead.
-func Shuffle[T any](slice []T) error {
-	n := len(slice)
+// Shuffle shuffles n elements using the Fisher-Yates algorithm; swap is
+// called to swap the elements with indexes i and j. For n smaller than 256, it
+// uses a batch of random bytes to avoid repeated RNG calls and mutex overhead.
+func (s *source) Shuffle(n int, swap func(i, j int)) error {
 	if n <= 1 {
 		return nil
 	}
@@ -138,23 +155,22 @@ func Shuffle[T any](slice []T) error {
 	if n <= 256 {
 		var stackBuf [256]byte
 		b := stackBuf[:n-1]
-		if err := readBytesBuffered(b); err != nil {
+		if err := s.read(b); err != nil {
 			return err
 		}
 		for i := n - 1; i > 0; i-- {
-			j := int(b[n-1-i]) % (i + 1)
-			slice[i], slice[j] = slice[j], slice[i]
+			swap(i, int(b[n-1-i])%(i+1))
 		}
 		return nil
 	}
 
 	// For larger slices, fall back to individual IntN calls.
 	for i := n - 1; i > 0; i-- {
-		j, err := IntN(i + 1)
+		j, err := s.IntN(i + 1)
 		if err != nil {
 			return err
 		}
-		slice[i], slice[j] = slice[j], slice[i]
+		swap(i, j)
 	}
 	return nil
 }
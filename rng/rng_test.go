@@ -1,7 +1,9 @@
 package rng
 
 import (
+	"bytes"
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -167,6 +169,92 @@ func TestShuffle(t *testing.T) {
 	})
 }
 
+func TestSource(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		assert.NotNil(t, Default())
+		assert.Equal(t, Default(), Default())
+	})
+
+	t.Run("reproducible", func(t *testing.T) {
+		s1 := NewSource(rand.New(rand.NewSource(0)))
+		s2 := NewSource(rand.New(rand.NewSource(0)))
+		for idx := 0; idx < 100; idx++ {
+			n1, err := s1.IntN(1000)
+			assert.NoError(t, err)
+			n2, err := s2.IntN(1000)
+			assert.NoError(t, err)
+			assert.Equal(t, n1, n2)
+		}
+
+		buf1, buf2 := make([]int, 100), make([]int, 100)
+		assert.NoError(t, s1.FillIntNs(buf1, 62))
+		assert.NoError(t, s2.FillIntNs(buf2, 62))
+		assert.Equal(t, buf1, buf2)
+
+		slice1 := []rune("abcdefghijklmnopqrstuvwxyz")
+		slice2 := []rune("abcdefghijklmnopqrstuvwxyz")
+		assert.NoError(t, s1.Shuffle(len(slice1), func(i, j int) { slice1[i], slice1[j] = slice1[j], slice1[i] }))
+		assert.NoError(t, s2.Shuffle(len(slice2), func(i, j int) { slice2[i], slice2[j] = slice2[j], slice2[i] }))
+		assert.Equal(t, string(slice1), string(slice2))
+
+		b1, b2 := make([]byte, 2000), make([]byte, 2000)
+		n, err := s1.Read(b1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2000, n)
+		_, err = s2.Read(b2)
+		assert.NoError(t, err)
+		assert.Equal(t, b1, b2)
+	})
+
+	t.Run("fixed bytes", func(t *testing.T) {
+		s := NewSource(bytes.NewReader([]byte{0, 1, 2, 3, 4}))
+		for idx := 0; idx < 5; idx++ {
+			n, err := s.IntN(10)
+			assert.NoError(t, err)
+			assert.Equal(t, idx, n)
+		}
+
+		// the reader is exhausted now
+		_, err := s.IntN(10)
+		assert.Error(t, err)
+		assert.Error(t, s.FillIntNs(make([]int, 4), 10))
+		assert.Error(t, s.Shuffle(4, func(i, j int) {}))
+	})
+
+	t.Run("large reads skip the buffer", func(t *testing.T) {
+		r := &recordingReader{}
+		s := NewSource(r)
+		n, err := s.Read(make([]byte, bufferSize))
+		assert.NoError(t, err)
+		assert.Equal(t, bufferSize, n)
+		_, err = s.IntN(10)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{bufferSize, bufferSize}, r.sizes)
+
+		// small reads go through the buffer filled by the IntN call
+		_, err = s.Read(make([]byte, bufferSize/2))
+		assert.NoError(t, err)
+		assert.Equal(t, []int{bufferSize, bufferSize}, r.sizes)
+		_, err = s.Read(make([]byte, bufferSize/2+1))
+		assert.NoError(t, err)
+		assert.Equal(t, []int{bufferSize, bufferSize, bufferSize/2 + 1}, r.sizes)
+
+		// the other methods read through the buffer without allocating
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _, _ = s.IntN(1000) }))
+	})
+}
+
+// recordingReader is an io.Reader of zeroes recording the size of every read.
+type recordingReader struct {
+	sizes []int
+}
+
+func (r *recordingReader) Read(b []byte) (int, error) {
+	r.sizes = append(r.sizes, len(b))
+	clear(b)
+	return len(b), nil
+}
+
 func equalSlices(a, b []rune) bool {
 	if len(a) != len(b) {
 		return false
@@ -0,0 +1,63 @@
+package rng
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+)
+
+var (
+	// defaultSource is the buffered crypto/rand Source used by the package
+	// level functions.
+	defaultSource = newSource(rand.Reader)
+)
+
+// Source defines interfaces to a source of randomness.
+type Source interface {
+	// Read fills the given buffer with random bytes.
+	io.Reader
+	// IntN returns a random integer in [0, n).
+	IntN(n int) (int, error)
+	// FillIntNs fills the provided slice with random integers in [0, n).
+	FillIntNs(buf []int, n int) error
+	// Shuffle shuffles n elements using the Fisher-Yates algorithm; swap is
+	// called to swap the elements with indexes i and j.
+	Shuffle(n int, swap func(i, j int)) error
+}
+
+type source struct {
+	reader io.Reader
+	buf    [bufferSize]byte
+	pos    int
+	end    int
+	mutex  sync.Mutex
+}
+
+// Default returns the Source backed by buffered crypto/rand.
+func Default() Source {
+	return defaultSource
+}
+
+// NewSource returns a Source that reads its random bytes from the given
+// io.Reader. The bytes are buffered, and so the same sequence of bytes from
+// the reader results in the same sequence of values from the Source.
+func NewSource(r io.Reader) Source {
+	return newSource(r)
+}
+
+func newSource(r io.Reader) *source {
+	return &source{reader: r}
+}
+
+// Read fills the given buffer with random bytes.
+func (s *source) Read(b []byte) (int, error) {
+	read := s.read
+	if len(b) > bufferSize/2 {
+		// for large requests, skip the buffer and read directly
+		read = s.readDirect
+	}
+	if err := read(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -0,0 +1,190 @@
+package strength
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// dateSeparators are the characters commonly used to separate the parts
+	// of a date.
+	dateSeparators = " -./\\_"
+	// minYearSpace is the minimum number of years considered when guessing a
+	// year, even if it is very close to the reference year.
+	minYearSpace = 20
+)
+
+var (
+	// dateSplits contain the ways to split dates without separators into
+	// three parts: [:k], [k:l] and [l:]
+	dateSplits = map[int][][2]int{
+		4: {{1, 2}, {2, 3}},         // 1 1 91 | 11 1 1
+		5: {{1, 3}, {2, 3}},         // 1 11 91 | 11 1 91
+		6: {{1, 2}, {2, 4}, {4, 5}}, // 1 1 1991 | 11 11 91 | 1991 1 1
+		7: {{1, 3}, {2, 3}, {4, 5}, {4, 6}},
+		8: {{2, 4}, {4, 6}}, // 11 11 1991 | 1991 11 11
+	}
+)
+
+// dateMatches returns all the dates (like "1991", "13-05-1991" or "130591")
+// found in the password.
+func (e *estimator) dateMatches(password []rune) []Match {
+	var rsp []Match
+	for i := 0; i < len(password); i++ {
+		for j := i + 4; j <= len(password) && j <= i+10; j++ {
+			token := string(password[i:j])
+			var candidates []Match
+			if allDigits(token) {
+				candidates = e.datesWithoutSeparator(token)
+			} else if j-i >= 6 {
+				candidates = e.datesWithSeparator(token)
+			}
+			for _, m := range candidates {
+				m.Pattern, m.Start, m.End, m.Token = Date, i, j, token
+				m.Guesses = e.dateGuesses(m)
+				rsp = append(rsp, m)
+			}
+		}
+	}
+
+	// ignore dates that are a part of other dates, like "1991" in "13-05-1991"
+	var filtered []Match
+	for _, m := range rsp {
+		within := false
+		for _, other := range rsp {
+			if other.Start <= m.Start && other.End >= m.End && other.End-other.Start > m.End-m.Start {
+				within = true
+				break
+			}
+		}
+		if !within {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+// datesWithoutSeparator returns the most likely date (and/or year) that the
+// digits in the token represent.
+func (e *estimator) datesWithoutSeparator(token string) []Match {
+	var rsp []Match
+	if year, _ := strconv.Atoi(token); len(token) == 4 && year >= 1900 && year <= 2099 {
+		rsp = append(rsp, Match{Year: year})
+	}
+
+	var best *Match
+	for _, split := range dateSplits[len(token)] {
+		m, ok := e.toDate(token[:split[0]], token[split[0]:split[1]], token[split[1]:])
+		if ok && (best == nil || e.yearDistance(m.Year) < e.yearDistance(best.Year)) {
+			best = &m
+		}
+	}
+	if best != nil {
+		rsp = append(rsp, *best)
+	}
+	return rsp
+}
+
+// datesWithSeparator returns the date the token represents if it is made up of
+// three numbers split by the same separator.
+func (e *estimator) datesWithSeparator(token string) []Match {
+	for _, sep := range dateSeparators {
+		parts := strings.Split(token, string(sep))
+		if len(parts) != 3 || len(parts[0]) > 4 || len(parts[1]) > 2 || len(parts[2]) > 4 {
+			continue
+		}
+		if !allDigits(parts[0]) || !allDigits(parts[1]) || !allDigits(parts[2]) {
+			continue
+		}
+		if m, ok := e.toDate(parts[0], parts[1], parts[2]); ok {
+			m.Separator = string(sep)
+			return []Match{m}
+		}
+	}
+	return nil
+}
+
+// toDate maps the three numbers to a day, month and year with the year
+// either at the start or at the end; the year closest to the reference year
+// wins if both are valid.
+func (e *estimator) toDate(a, b, c string) (Match, bool) {
+	var rsp Match
+	found := false
+	for _, parts := range [][3]string{{c, a, b}, {a, b, c}} {
+		year, ok := toYear(parts[0])
+		if !ok {
+			continue
+		}
+		day, month, ok := toDayMonth(parts[1], parts[2])
+		if !ok {
+			continue
+		}
+		if !found || e.yearDistance(year) < e.yearDistance(rsp.Year) {
+			rsp = Match{Day: day, Month: month, Year: year}
+			found = true
+		}
+	}
+	return rsp, found
+}
+
+// dateGuesses returns the number of guesses needed to find the date.
+func (e *estimator) dateGuesses(m Match) float64 {
+	guesses := math.Max(float64(e.yearDistance(m.Year)), minYearSpace)
+	if m.Day > 0 {
+		guesses *= 365
+	}
+	if m.Separator != "" {
+		guesses *= 4
+	}
+	return guesses
+}
+
+func (e *estimator) yearDistance(year int) int {
+	if year > e.referenceYear {
+		return year - e.referenceYear
+	}
+	return e.referenceYear - year
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// toDayMonth returns the day and the month represented by the two numbers in
+// either order.
+func toDayMonth(a, b string) (int, int, bool) {
+	x, _ := strconv.Atoi(a)
+	y, _ := strconv.Atoi(b)
+	if len(a) <= 2 && len(b) <= 2 {
+		if x >= 1 && x <= 31 && y >= 1 && y <= 12 {
+			return x, y, true
+		}
+		if y >= 1 && y <= 31 && x >= 1 && x <= 12 {
+			return y, x, true
+		}
+	}
+	return 0, 0, false
+}
+
+// toYear returns the year represented by the number; two-digit years are
+// mapped to the closest century.
+func toYear(s string) (int, bool) {
+	year, _ := strconv.Atoi(s)
+	switch len(s) {
+	case 2:
+		if year > 50 {
+			return 1900 + year, true
+		}
+		return 2000 + year, true
+	case 4:
+		return year, year >= 1000 && year <= 2050
+	}
+	return 0, false
+}
@@ -0,0 +1,71 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator_dateMatches(t *testing.T) {
+	e := NewEstimator(WithReferenceYear(2024)).(*estimator)
+
+	t.Run("with separators", func(t *testing.T) {
+		matches := e.dateMatches([]rune("x13-05-1991x"))
+		assert.Len(t, matches, 1)
+		assert.Equal(t, "13-05-1991", matches[0].Token)
+		assert.Equal(t, 13, matches[0].Day)
+		assert.Equal(t, 5, matches[0].Month)
+		assert.Equal(t, 1991, matches[0].Year)
+		assert.Equal(t, "-", matches[0].Separator)
+		assert.Equal(t, 33.0*365*4, matches[0].Guesses)
+
+		matches = e.dateMatches([]rune("1991/05/13"))
+		assert.Len(t, matches, 1)
+		assert.Equal(t, 1991, matches[0].Year)
+
+		matches = e.dateMatches([]rune("13-05/1991"))
+		assert.Len(t, matches, 2)
+		for _, m := range matches {
+			assert.Equal(t, "1991", m.Token)
+			assert.Equal(t, "", m.Separator)
+		}
+	})
+
+	t.Run("without separators", func(t *testing.T) {
+		matches := e.dateMatches([]rune("13051991"))
+		assert.Len(t, matches, 1)
+		assert.Equal(t, 13, matches[0].Day)
+		assert.Equal(t, 5, matches[0].Month)
+		assert.Equal(t, 1991, matches[0].Year)
+		assert.Equal(t, "", matches[0].Separator)
+		assert.Equal(t, 33.0*365, matches[0].Guesses)
+
+		matches = e.dateMatches([]rune("130591"))
+		assert.Len(t, matches, 1)
+		assert.Equal(t, 1991, matches[0].Year)
+	})
+
+	t.Run("years", func(t *testing.T) {
+		matches := e.dateMatches([]rune("abc2020"))
+		assert.Len(t, matches, 1)
+		assert.Equal(t, 2020, matches[0].Year)
+		assert.Equal(t, 0, matches[0].Day)
+		assert.Equal(t, float64(minYearSpace), matches[0].Guesses)
+	})
+}
+
+func Test_toYear(t *testing.T) {
+	year, ok := toYear("91")
+	assert.True(t, ok)
+	assert.Equal(t, 1991, year)
+	year, ok = toYear("07")
+	assert.True(t, ok)
+	assert.Equal(t, 2007, year)
+	year, ok = toYear("1991")
+	assert.True(t, ok)
+	assert.Equal(t, 1991, year)
+	_, ok = toYear("2091")
+	assert.False(t, ok)
+	_, ok = toYear("191")
+	assert.False(t, ok)
+}
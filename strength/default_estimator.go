@@ -0,0 +1,20 @@
+package strength
+
+import "sync"
+
+var (
+	defaultEstimator     Estimator
+	defaultEstimatorOnce sync.Once
+)
+
+// Estimate returns the estimated strength of the given password using the
+// following rules:
+// * looks for the most common passwords
+// * looks for English words
+// * looks for keyboard walks, repeats, sequences and dates
+func Estimate(password string) Result {
+	defaultEstimatorOnce.Do(func() {
+		defaultEstimator = NewEstimator()
+	})
+	return defaultEstimator.Estimate(password)
+}
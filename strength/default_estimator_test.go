@@ -0,0 +1,15 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate(t *testing.T) {
+	r := Estimate("password")
+	assert.Equal(t, 0, r.Score)
+	assert.Equal(t, WarningCommonPassword, r.Feedback.Warning)
+	assert.Len(t, r.Sequence, 1)
+	assert.Equal(t, Dictionary, r.Sequence[0].Pattern)
+}
@@ -0,0 +1,299 @@
+package strength
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// l33tTable maps l33t characters to the letters they commonly substitute.
+	l33tTable = map[rune][]rune{
+		'!': {'i'},
+		'$': {'s'},
+		'%': {'x'},
+		'(': {'c'},
+		'+': {'t'},
+		'0': {'o'},
+		'1': {'i', 'l'},
+		'2': {'z'},
+		'3': {'e'},
+		'4': {'a'},
+		'5': {'s'},
+		'6': {'g'},
+		'7': {'t', 'l'},
+		'8': {'b'},
+		'9': {'g'},
+		'<': {'c'},
+		'@': {'a'},
+		'[': {'c'},
+		'{': {'c'},
+		'|': {'i', 'l'},
+	}
+)
+
+// dictionary is a list of words along with the number of guesses needed to
+// find each word.
+type dictionary struct {
+	name   string
+	ranks  map[string]int
+	minLen int
+	maxLen int
+}
+
+// newDictionary returns a dictionary of words in no particular order; the rank
+// of each word is the number of words in the dictionary with the same length.
+func newDictionary(name string, words []string) *dictionary {
+	words = uniqueWords(words)
+	numWordsByLength := make(map[int]int)
+	for _, word := range words {
+		numWordsByLength[utf8.RuneCountInString(word)]++
+	}
+
+	d := &dictionary{name: name, ranks: make(map[string]int, len(words))}
+	for _, word := range words {
+		d.add(word, numWordsByLength[utf8.RuneCountInString(word)])
+	}
+	return d
+}
+
+// newRankedDictionary returns a dictionary of words sorted by their frequency
+// of use, with the most common word first.
+func newRankedDictionary(name string, words []string) *dictionary {
+	d := &dictionary{name: name, ranks: make(map[string]int, len(words))}
+	for idx, word := range uniqueWords(words) {
+		d.add(word, idx+1)
+	}
+	return d
+}
+
+func (d *dictionary) add(word string, rank int) {
+	wordLen := utf8.RuneCountInString(word)
+	if d.minLen == 0 || wordLen < d.minLen {
+		d.minLen = wordLen
+	}
+	if wordLen > d.maxLen {
+		d.maxLen = wordLen
+	}
+	d.ranks[word] = rank
+}
+
+// matches returns all the substrings of the password found in the dictionary.
+func (d *dictionary) matches(password []rune) []Match {
+	var rsp []Match
+	passwordLower := toLower(password)
+	for i := 0; i < len(password); i++ {
+		for j := i + d.minLen; j <= len(password) && j-i <= d.maxLen; j++ {
+			word := string(passwordLower[i:j])
+			if rank, ok := d.ranks[word]; ok {
+				rsp = append(rsp, Match{
+					Pattern:        Dictionary,
+					Start:          i,
+					End:            j,
+					Token:          string(password[i:j]),
+					DictionaryName: d.name,
+					MatchedWord:    word,
+					Rank:           rank,
+				})
+			}
+		}
+	}
+	return rsp
+}
+
+// dictionaryMatches returns all the words from all the dictionaries found in
+// the password, as is or in reverse.
+func (e *estimator) dictionaryMatches(password []rune) []Match {
+	var rsp []Match
+	for _, d := range e.dictionaries {
+		rsp = append(rsp, d.matches(password)...)
+
+		reversed := reverse(password)
+		for _, m := range d.matches(reversed) {
+			token := reverse(reversed[m.Start:m.End])
+			if string(token) == m.Token {
+				continue // palindromes are already matched as is
+			}
+			m.Start, m.End = len(password)-m.End, len(password)-m.Start
+			m.Token = string(token)
+			m.Reversed = true
+			rsp = append(rsp, m)
+		}
+	}
+	for idx := range rsp {
+		rsp[idx].Guesses = dictionaryGuesses(rsp[idx])
+	}
+	return rsp
+}
+
+// l33tMatches returns all the words from all the dictionaries found in the
+// password after undoing l33t substitutions.
+func (e *estimator) l33tMatches(password []rune) []Match {
+	var rsp []Match
+	type l33tMatch struct {
+		dictionary string
+		start, end int
+		word       string
+	}
+	seen := make(map[l33tMatch]bool)
+	for _, subs := range l33tSubstitutions(password) {
+		unl33ted := make([]rune, len(password))
+		for idx, r := range password {
+			if sub, ok := subs[r]; ok {
+				r = sub
+			}
+			unl33ted[idx] = r
+		}
+
+		for _, d := range e.dictionaries {
+			for _, m := range d.matches(unl33ted) {
+				token := password[m.Start:m.End]
+				usedSubs := make(map[rune]rune)
+				for _, r := range token {
+					if sub, ok := subs[r]; ok {
+						usedSubs[r] = sub
+					}
+				}
+				// ignore single-character l33t matches like "4" ==> "a"
+				if len(usedSubs) == 0 || len(token) == 1 {
+					continue
+				}
+
+				key := l33tMatch{dictionary: d.name, start: m.Start, end: m.End, word: m.MatchedWord}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				m.Token = string(token)
+				m.L33t = true
+				m.L33tSubstitutions = usedSubs
+				m.Guesses = dictionaryGuesses(m)
+				rsp = append(rsp, m)
+			}
+		}
+	}
+	return rsp
+}
+
+// l33tSubstitutions returns all the possible substitution tables for the l33t
+// characters in the password; as very few l33t characters are ambiguous, there
+// are only a handful of tables at most.
+func l33tSubstitutions(password []rune) []map[rune]rune {
+	var l33tChars []rune
+	seen := make(map[rune]bool)
+	for _, r := range password {
+		if _, ok := l33tTable[r]; ok && !seen[r] {
+			l33tChars = append(l33tChars, r)
+			seen[r] = true
+		}
+	}
+	if len(l33tChars) == 0 {
+		return nil
+	}
+
+	rsp := []map[rune]rune{{}}
+	for _, r := range l33tChars {
+		var next []map[rune]rune
+		for _, subs := range rsp {
+			for _, letter := range l33tTable[r] {
+				nextSubs := make(map[rune]rune, len(subs)+1)
+				for k, v := range subs {
+					nextSubs[k] = v
+				}
+				nextSubs[r] = letter
+				next = append(next, nextSubs)
+			}
+		}
+		rsp = next
+	}
+	return rsp
+}
+
+// dictionaryGuesses returns the number of guesses needed to find the word
+// along with its variations.
+func dictionaryGuesses(m Match) float64 {
+	guesses := float64(m.Rank) * uppercaseVariations([]rune(m.Token)) * l33tVariations(m)
+	if m.Reversed {
+		guesses *= 2
+	}
+	return guesses
+}
+
+// uppercaseVariations returns the number of ways the word could have been
+// capitalized.
+func uppercaseVariations(word []rune) float64 {
+	numUpper, numLower := 0, 0
+	for _, r := range word {
+		if unicode.IsUpper(r) {
+			numUpper++
+		} else if unicode.IsLower(r) {
+			numLower++
+		}
+	}
+	if numUpper == 0 {
+		return 1
+	}
+
+	// capitalized, all upper-case, and last character upper-case are the most
+	// common variations
+	firstUpper := unicode.IsUpper(word[0])
+	lastUpper := unicode.IsUpper(word[len(word)-1])
+	if numLower == 0 || (numUpper == 1 && (firstUpper || lastUpper)) {
+		return 2
+	}
+	return variations(numUpper, numLower)
+}
+
+// l33tVariations returns the number of ways the l33t substitutions could have
+// been applied to the word.
+func l33tVariations(m Match) float64 {
+	if !m.L33t {
+		return 1
+	}
+
+	rsp := 1.0
+	tokenLower := toLower([]rune(m.Token))
+	for l33tChar, letter := range m.L33tSubstitutions {
+		numSubbed, numUnsubbed := 0, 0
+		for _, r := range tokenLower {
+			if r == l33tChar {
+				numSubbed++
+			} else if r == letter {
+				numUnsubbed++
+			}
+		}
+		if numSubbed == 0 || numUnsubbed == 0 {
+			// either all or none of the letters were substituted
+			rsp *= 2
+		} else {
+			rsp *= variations(numSubbed, numUnsubbed)
+		}
+	}
+	return rsp
+}
+
+// uniqueWords returns the lower-cased non-empty words without duplicates while
+// retaining the order.
+func uniqueWords(words []string) []string {
+	var rsp []string
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" && !seen[word] {
+			rsp = append(rsp, word)
+			seen[word] = true
+		}
+	}
+	return rsp
+}
+
+// variations returns the number of ways to pick up to min(a, b) items from a
+// set of a+b items.
+func variations(a, b int) float64 {
+	rsp := 0.0
+	for idx := 1; idx <= min(a, b); idx++ {
+		rsp += binomial(a+b, idx)
+	}
+	return rsp
+}
@@ -0,0 +1,63 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator_dictionaryMatches(t *testing.T) {
+	e := NewEstimator(WithRankedDictionary("test", []string{"foo", "bar", "Hello"})).(*estimator)
+	e.dictionaries = e.dictionaries[len(e.dictionaries)-1:]
+
+	matches := e.dictionaryMatches([]rune("xFOObarollehz"))
+	assert.Len(t, matches, 3)
+	assert.Equal(t, "FOO", matches[0].Token)
+	assert.Equal(t, "foo", matches[0].MatchedWord)
+	assert.Equal(t, 1, matches[0].Start)
+	assert.Equal(t, 4, matches[0].End)
+	assert.Equal(t, 1, matches[0].Rank)
+	assert.Equal(t, 2.0, matches[0].Guesses) // all upper-case
+	assert.Equal(t, "bar", matches[1].Token)
+	assert.Equal(t, 2.0, matches[1].Guesses)
+	assert.Equal(t, "olleh", matches[2].Token)
+	assert.Equal(t, "hello", matches[2].MatchedWord)
+	assert.True(t, matches[2].Reversed)
+	assert.Equal(t, 6.0, matches[2].Guesses) // rank 3, reversed
+}
+
+func TestEstimator_l33tMatches(t *testing.T) {
+	e := NewEstimator(WithRankedDictionary("test", []string{"password", "lol"})).(*estimator)
+	e.dictionaries = e.dictionaries[len(e.dictionaries)-1:]
+
+	matches := e.l33tMatches([]rune("p@ssw0rd|0|"))
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "p@ssw0rd", matches[0].Token)
+	assert.Equal(t, "password", matches[0].MatchedWord)
+	assert.Equal(t, map[rune]rune{'@': 'a', '0': 'o'}, matches[0].L33tSubstitutions)
+	assert.Equal(t, 4.0, matches[0].Guesses)
+	assert.Equal(t, "|0|", matches[1].Token)
+	assert.Equal(t, "lol", matches[1].MatchedWord)
+	assert.Equal(t, 8.0, matches[1].Guesses)
+
+	assert.Empty(t, e.l33tMatches([]rune("password")))
+}
+
+func TestNewDictionary(t *testing.T) {
+	d := newDictionary("test", []string{"abc", "def", "ABC", "ghij", " ", ""})
+	assert.Equal(t, map[string]int{"abc": 2, "def": 2, "ghij": 1}, d.ranks)
+	assert.Equal(t, 3, d.minLen)
+	assert.Equal(t, 4, d.maxLen)
+
+	d = newRankedDictionary("test", []string{"abc", "def", "ABC", "ghij"})
+	assert.Equal(t, map[string]int{"abc": 1, "def": 2, "ghij": 3}, d.ranks)
+}
+
+func Test_uppercaseVariations(t *testing.T) {
+	assert.Equal(t, 1.0, uppercaseVariations([]rune("password")))
+	assert.Equal(t, 1.0, uppercaseVariations([]rune("123")))
+	assert.Equal(t, 2.0, uppercaseVariations([]rune("Password")))
+	assert.Equal(t, 2.0, uppercaseVariations([]rune("passworD")))
+	assert.Equal(t, 2.0, uppercaseVariations([]rune("PASSWORD")))
+	assert.Equal(t, binomial(8, 1)+binomial(8, 2), uppercaseVariations([]rune("PassWord")))
+}
@@ -0,0 +1,173 @@
+package strength
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// MaxLength is the maximum number of characters in a password that are
+	// analyzed for patterns; characters beyond this are ignored like zxcvbn
+	// does, as they may just repeat the ones before.
+	MaxLength = 100
+
+	// minGuessesBeforeGrowingSequence penalizes passwords made of many small
+	// patterns, i.e., guessing a sequence of N patterns costs at least
+	// minGuessesBeforeGrowingSequence^(N-1) guesses.
+	minGuessesBeforeGrowingSequence = 10000
+	// minSubMatchGuessesSingleChar is the minimum number of guesses for a
+	// single character pattern that is part of a longer password.
+	minSubMatchGuessesSingleChar = 10
+	// minSubMatchGuessesMultiChar is the minimum number of guesses for a multi
+	// character pattern that is part of a longer password.
+	minSubMatchGuessesMultiChar = 50
+)
+
+// Score thresholds (in log10 of the number of guesses) to go from one Score to
+// the next one.
+var (
+	scoreThresholds = []float64{
+		math.Log10(1e3 + 5),
+		math.Log10(1e6 + 5),
+		math.Log10(1e8 + 5),
+		math.Log10(1e10 + 5),
+	}
+)
+
+// Estimator defines interfaces to estimate the strength of passwords.
+type Estimator interface {
+	// Estimate returns the estimated strength of the given password.
+	Estimate(password string) Result
+}
+
+// Result contains the estimated strength of a password.
+type Result struct {
+	// Password is the password that was analyzed.
+	Password string
+	// Guesses is the estimated number of guesses needed to crack the password.
+	Guesses float64
+	// GuessesLog10 is log10(Guesses), and is accurate even if Guesses
+	// overflows to +Inf.
+	GuessesLog10 float64
+	// Entropy is the estimated entropy of the password in bits, i.e.,
+	// log2(Guesses).
+	Entropy float64
+	// Score ranges from 0 (too guessable) to 4 (very unguessable).
+	Score int
+	// Sequence is the list of patterns the password (up to MaxLength
+	// characters) was broken into, which results in the least number of
+	// guesses.
+	Sequence []Match
+	// Feedback contains a warning and suggestions to improve the password.
+	Feedback Feedback
+}
+
+type estimator struct {
+	dictionaries  []*dictionary
+	referenceYear int
+}
+
+// NewEstimator returns a password strength estimator that implements the
+// Estimator interface.
+func NewEstimator(rules ...Rule) Estimator {
+	e := &estimator{
+		referenceYear: time.Now().Year(),
+	}
+	for _, opt := range append(basicRules, rules...) {
+		opt(e)
+	}
+	return e
+}
+
+// Estimate returns the estimated strength of the given password.
+func (e *estimator) Estimate(password string) Result {
+	runes := []rune(password)
+	if len(runes) > MaxLength {
+		runes = runes[:MaxLength]
+	}
+
+	sequence, guessesLog10 := e.mostGuessableSequence(runes)
+
+	rsp := Result{
+		Password:     password,
+		Guesses:      math.Pow(10, guessesLog10),
+		GuessesLog10: guessesLog10,
+		Entropy:      guessesLog10 / math.Log10(2),
+		Score:        score(guessesLog10),
+		Sequence:     sequence,
+	}
+	rsp.Feedback = feedback(rsp.Score, sequence)
+	return rsp
+}
+
+// guesses returns the estimated number of guesses to crack the given password
+// in log10.
+func (e *estimator) guesses(password []rune) float64 {
+	_, guessesLog10 := e.mostGuessableSequence(password)
+	return guessesLog10
+}
+
+// matches returns all the patterns found in the password sorted by their
+// position in the password.
+func (e *estimator) matches(password []rune) []Match {
+	var rsp []Match
+	rsp = append(rsp, e.dictionaryMatches(password)...)
+	rsp = append(rsp, e.l33tMatches(password)...)
+	rsp = append(rsp, spatialMatches(password)...)
+	rsp = append(rsp, e.repeatMatches(password)...)
+	rsp = append(rsp, sequenceMatches(password)...)
+	rsp = append(rsp, e.dateMatches(password)...)
+	sort.SliceStable(rsp, func(i, j int) bool {
+		if rsp[i].Start != rsp[j].Start {
+			return rsp[i].Start < rsp[j].Start
+		}
+		return rsp[i].End < rsp[j].End
+	})
+	return rsp
+}
+
+// mostGuessableSequence finds the sequence of non-overlapping matches (with
+// the gaps filled by brute-force matches) that needs the least number of
+// guesses to crack, and returns it along with the number of guesses in log10.
+//
+// The number of guesses for a sequence of N matches is:
+// N! * product(guesses of each match) + minGuessesBeforeGrowingSequence^(N-1)
+func (e *estimator) mostGuessableSequence(password []rune) ([]Match, float64) {
+	n := len(password)
+	if n == 0 {
+		return nil, 0
+	}
+
+	matchesByEnd := make([][]Match, n)
+	for _, m := range e.matches(password) {
+		m.setMinGuesses(n)
+		matchesByEnd[m.End-1] = append(matchesByEnd[m.End-1], m)
+	}
+
+	s := newSequenceSearch(n)
+	for k := 0; k < n; k++ {
+		for _, m := range matchesByEnd[k] {
+			if m.Start == 0 {
+				s.update(m, 1)
+				continue
+			}
+			for l, entry := range s.entries[m.Start-1] {
+				if entry.set {
+					s.update(m, l+1)
+				}
+			}
+		}
+		s.bruteForceUpdate(password, k)
+	}
+	return s.unwind()
+}
+
+func score(guessesLog10 float64) int {
+	for idx, threshold := range scoreThresholds {
+		if guessesLog10 < threshold {
+			return idx
+		}
+	}
+	return len(scoreThresholds)
+}
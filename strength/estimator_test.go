@@ -0,0 +1,88 @@
+package strength
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator_Estimate(t *testing.T) {
+	e := NewEstimator(WithReferenceYear(2024))
+
+	t.Run("empty", func(t *testing.T) {
+		r := e.Estimate("")
+		assert.Equal(t, 1.0, r.Guesses)
+		assert.Equal(t, 0, r.Score)
+		assert.Empty(t, r.Sequence)
+		assert.NotEmpty(t, r.Feedback.Suggestions)
+	})
+
+	t.Run("weak passwords", func(t *testing.T) {
+		for _, pw := range []string{"password", "P@ssw0rd", "qwerty", "aaaaaaa", "abcdef", "1991", "drowssap"} {
+			r := e.Estimate(pw)
+			assert.Equal(t, 0, r.Score, pw)
+			assert.NotEmpty(t, r.Feedback.Warning, pw)
+		}
+	})
+
+	t.Run("strong passwords", func(t *testing.T) {
+		for _, pw := range []string{"correcthorsebatterystaple", "xK9#mQ2$vL8!", "Tr0ub4dor&3"} {
+			r := e.Estimate(pw)
+			assert.Equal(t, 4, r.Score, pw)
+			assert.Empty(t, r.Feedback.Warning, pw)
+			assert.Empty(t, r.Feedback.Suggestions, pw)
+		}
+	})
+
+	t.Run("sequence covers the password", func(t *testing.T) {
+		pw := "Jedi!qwerty13-05-1991aaaa"
+		r := e.Estimate(pw)
+		assert.Equal(t, pw, r.Password)
+		var sb strings.Builder
+		for idx, m := range r.Sequence {
+			if idx > 0 {
+				assert.Equal(t, r.Sequence[idx-1].End, m.Start)
+			}
+			sb.WriteString(m.Token)
+		}
+		assert.Equal(t, pw, sb.String())
+		assert.InDelta(t, math.Log10(r.Guesses), r.GuessesLog10, 0.000001)
+		assert.InDelta(t, math.Log2(r.Guesses), r.Entropy, 0.000001)
+	})
+
+	t.Run("beyond max length", func(t *testing.T) {
+		pw := strings.Repeat("ab", 500)
+		r := e.Estimate(pw)
+		assert.Equal(t, pw, r.Password)
+		assert.Equal(t, MaxLength, r.Sequence[len(r.Sequence)-1].End)
+		assert.Equal(t, e.Estimate(pw[:MaxLength]).GuessesLog10, r.GuessesLog10)
+		assert.LessOrEqual(t, r.Score, 1)
+	})
+
+	t.Run("longer is stronger", func(t *testing.T) {
+		assert.Less(t, e.Estimate("monkey").Guesses, e.Estimate("monkeybusiness").Guesses)
+		assert.Less(t, e.Estimate("kdjf").Guesses, e.Estimate("kdjfqpzm").Guesses)
+	})
+}
+
+func TestEstimator_WithUserInputs(t *testing.T) {
+	pw := "jedib0t2024"
+	withoutInputs := NewEstimator().Estimate(pw)
+	withInputs := NewEstimator(WithUserInputs("jedibot")).Estimate(pw)
+	assert.Less(t, withInputs.Guesses, withoutInputs.Guesses)
+	assert.Equal(t, dictionaryUserInputs, withInputs.Sequence[0].DictionaryName)
+	assert.True(t, withInputs.Sequence[0].L33t)
+}
+
+func Test_score(t *testing.T) {
+	assert.Equal(t, 0, score(0))
+	assert.Equal(t, 0, score(3))
+	assert.Equal(t, 1, score(3.01))
+	assert.Equal(t, 1, score(6))
+	assert.Equal(t, 2, score(7.5))
+	assert.Equal(t, 3, score(9.9))
+	assert.Equal(t, 4, score(10.01))
+	assert.Equal(t, 4, score(100))
+}
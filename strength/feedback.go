@@ -0,0 +1,120 @@
+package strength
+
+import (
+	"unicode"
+)
+
+// Feedback contains a warning about, and suggestions to improve, a password.
+type Feedback struct {
+	// Warning explains what is wrong with the password, if anything.
+	Warning string
+	// Suggestions help choose a better password.
+	Suggestions []string
+}
+
+// Feedback messages.
+const (
+	SuggestionAddWord       = "Add another word or two. Uncommon words are better."
+	SuggestionAllUppercase  = "All-uppercase is almost as easy to guess as all-lowercase."
+	SuggestionAvoidDates    = "Avoid dates and years that are associated with you."
+	SuggestionAvoidRepeats  = "Avoid repeated words and characters."
+	SuggestionAvoidSequence = "Avoid sequences."
+	SuggestionCapitalize    = "Capitalization doesn't help very much."
+	SuggestionL33t          = "Predictable substitutions like '@' instead of 'a' don't help very much."
+	SuggestionLongerWalk    = "Use a longer keyboard pattern with more turns."
+	SuggestionNoSymbols     = "No need for symbols, digits, or upper-case letters."
+	SuggestionReversed      = "Reversed words aren't much harder to guess."
+	SuggestionUseFewWords   = "Use a few words, avoid common phrases."
+	WarningCommonPassword   = "This is a very common password."
+	WarningDates            = "Dates are often easy to guess."
+	WarningRepeatChars      = "Repeats like \"aaa\" are easy to guess."
+	WarningRepeatTokens     = "Repeats like \"abcabcabc\" are only slightly harder to guess than \"abc\"."
+	WarningSequence         = "Sequences like \"abc\" or \"6543\" are easy to guess."
+	WarningShortWalk        = "Short keyboard patterns are easy to guess."
+	WarningStraightRow      = "Straight rows of keys are easy to guess."
+	WarningUserInput        = "This is similar to a commonly used password or personal information."
+	WarningWordByItself     = "A word by itself is easy to guess."
+	WarningYears            = "Recent years are easy to guess."
+)
+
+// feedback returns the feedback for a password with the given score and
+// sequence of matches.
+func feedback(score int, sequence []Match) Feedback {
+	if len(sequence) == 0 {
+		return Feedback{Suggestions: []string{SuggestionUseFewWords, SuggestionNoSymbols}}
+	}
+	if score > 2 {
+		return Feedback{}
+	}
+
+	// the longest match is the most problematic one
+	longest := sequence[0]
+	for _, m := range sequence[1:] {
+		if m.End-m.Start > longest.End-longest.Start {
+			longest = m
+		}
+	}
+	rsp := matchFeedback(longest, len(sequence) == 1)
+	rsp.Suggestions = append([]string{SuggestionAddWord}, rsp.Suggestions...)
+	return rsp
+}
+
+func matchFeedback(m Match, isSoleMatch bool) Feedback {
+	switch m.Pattern {
+	case Date:
+		if m.Day == 0 {
+			return Feedback{Warning: WarningYears, Suggestions: []string{SuggestionAvoidDates}}
+		}
+		return Feedback{Warning: WarningDates, Suggestions: []string{SuggestionAvoidDates}}
+	case Dictionary:
+		return dictionaryFeedback(m, isSoleMatch)
+	case Repeat:
+		if len([]rune(m.BaseToken)) == 1 {
+			return Feedback{Warning: WarningRepeatChars, Suggestions: []string{SuggestionAvoidRepeats}}
+		}
+		return Feedback{Warning: WarningRepeatTokens, Suggestions: []string{SuggestionAvoidRepeats}}
+	case Sequence:
+		return Feedback{Warning: WarningSequence, Suggestions: []string{SuggestionAvoidSequence}}
+	case Spatial:
+		if m.Turns == 1 {
+			return Feedback{Warning: WarningStraightRow, Suggestions: []string{SuggestionLongerWalk}}
+		}
+		return Feedback{Warning: WarningShortWalk, Suggestions: []string{SuggestionLongerWalk}}
+	}
+	return Feedback{}
+}
+
+func dictionaryFeedback(m Match, isSoleMatch bool) Feedback {
+	var rsp Feedback
+	switch {
+	case m.DictionaryName == dictionaryPasswords && isSoleMatch && !m.L33t && !m.Reversed:
+		rsp.Warning = WarningCommonPassword
+	case m.DictionaryName == dictionaryPasswords || m.DictionaryName == dictionaryUserInputs:
+		rsp.Warning = WarningUserInput
+	case isSoleMatch:
+		rsp.Warning = WarningWordByItself
+	}
+
+	token := []rune(m.Token)
+	if uppercaseVariations(token) > 1 && !hasLower(token) {
+		rsp.Suggestions = append(rsp.Suggestions, SuggestionAllUppercase)
+	} else if unicode.IsUpper(token[0]) {
+		rsp.Suggestions = append(rsp.Suggestions, SuggestionCapitalize)
+	}
+	if m.Reversed && len(token) >= 4 {
+		rsp.Suggestions = append(rsp.Suggestions, SuggestionReversed)
+	}
+	if m.L33t {
+		rsp.Suggestions = append(rsp.Suggestions, SuggestionL33t)
+	}
+	return rsp
+}
+
+func hasLower(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.IsLower(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_feedback(t *testing.T) {
+	e := NewEstimator(WithReferenceYear(2024))
+
+	for pw, expected := range map[string]Feedback{
+		"": {Suggestions: []string{SuggestionUseFewWords, SuggestionNoSymbols}},
+		"password": {
+			Warning:     WarningCommonPassword,
+			Suggestions: []string{SuggestionAddWord},
+		},
+		"P@ssw0rd": {
+			Warning:     WarningUserInput,
+			Suggestions: []string{SuggestionAddWord, SuggestionCapitalize, SuggestionL33t},
+		},
+		"MONKEY": {
+			Warning:     WarningCommonPassword,
+			Suggestions: []string{SuggestionAddWord, SuggestionAllUppercase},
+		},
+		"tapestry": {
+			Warning:     WarningWordByItself,
+			Suggestions: []string{SuggestionAddWord},
+		},
+		"aaaaaaa": {
+			Warning:     WarningRepeatChars,
+			Suggestions: []string{SuggestionAddWord, SuggestionAvoidRepeats},
+		},
+		"abcabcabc": {
+			Warning:     WarningRepeatTokens,
+			Suggestions: []string{SuggestionAddWord, SuggestionAvoidRepeats},
+		},
+		"abcdef": {
+			Warning:     WarningSequence,
+			Suggestions: []string{SuggestionAddWord, SuggestionAvoidSequence},
+		},
+		"zxcvfr": {
+			Warning:     WarningShortWalk,
+			Suggestions: []string{SuggestionAddWord, SuggestionLongerWalk},
+		},
+		"13-05-1991": {
+			Warning:     WarningDates,
+			Suggestions: []string{SuggestionAddWord, SuggestionAvoidDates},
+		},
+		"1991": {
+			Warning:     WarningYears,
+			Suggestions: []string{SuggestionAddWord, SuggestionAvoidDates},
+		},
+		"correcthorsebatterystaple": {},
+	} {
+		assert.Equal(t, expected, e.Estimate(pw).Feedback, pw)
+	}
+}
@@ -0,0 +1,105 @@
+package strength
+
+import (
+	"math"
+)
+
+// Pattern is the kind of pattern found in a password.
+type Pattern string
+
+// Patterns that can be found in a password.
+const (
+	BruteForce Pattern = "bruteforce"
+	Date       Pattern = "date"
+	Dictionary Pattern = "dictionary"
+	Repeat     Pattern = "repeat"
+	Sequence   Pattern = "sequence"
+	Spatial    Pattern = "spatial"
+)
+
+// Match is a pattern found in a password.
+type Match struct {
+	// Pattern is the kind of pattern that was matched.
+	Pattern Pattern
+	// Start is the (rune) index in the password where the match starts.
+	Start int
+	// End is the (rune) index in the password where the match ends
+	// (exclusive).
+	End int
+	// Token is the part of the password that was matched.
+	Token string
+	// Guesses is the estimated number of guesses needed to crack the Token.
+	Guesses float64
+	// GuessesLog10 is log10(Guesses).
+	GuessesLog10 float64
+
+	// DictionaryName is the name of the dictionary the word was found in.
+	// (Dictionary)
+	DictionaryName string
+	// MatchedWord is the word from the dictionary that was matched.
+	// (Dictionary)
+	MatchedWord string
+	// Rank is the rank of the word in the dictionary. (Dictionary)
+	Rank int
+	// L33t is true if the Token had l33t substitutions. (Dictionary)
+	L33t bool
+	// L33tSubstitutions maps the substituted characters to the original
+	// letters. (Dictionary)
+	L33tSubstitutions map[rune]rune
+	// Reversed is true if the word was found in reverse. (Dictionary)
+	Reversed bool
+
+	// Turns is the number of changes in direction. (Spatial)
+	Turns int
+	// ShiftedCount is the number of characters typed with the Shift key.
+	// (Spatial)
+	ShiftedCount int
+
+	// BaseToken is the repeated part of the Token. (Repeat)
+	BaseToken string
+	// RepeatCount is the number of times the BaseToken repeats. (Repeat)
+	RepeatCount int
+
+	// Ascending is true if the sequence goes up. (Sequence)
+	Ascending bool
+
+	// Day of the month, or 0 if absent. (Date)
+	Day int
+	// Month of the year, or 0 if absent. (Date)
+	Month int
+	// Year, or 0 if absent. (Date)
+	Year int
+	// Separator between the day, month and year. (Date)
+	Separator string
+}
+
+// newBruteForceMatch returns a Match for brute-forcing password[start:end].
+func newBruteForceMatch(password []rune, start, end int) Match {
+	guessesLog10 := float64(end - start)
+	if end-start == 1 {
+		guessesLog10 = math.Log10(minSubMatchGuessesSingleChar + 1)
+	}
+	return Match{
+		Pattern:      BruteForce,
+		Start:        start,
+		End:          end,
+		Token:        string(password[start:end]),
+		Guesses:      math.Pow(10, guessesLog10),
+		GuessesLog10: guessesLog10,
+	}
+}
+
+// setMinGuesses ensures the match needs a reasonable minimum number of
+// guesses when it covers only a part of the password, and computes
+// GuessesLog10.
+func (m *Match) setMinGuesses(passwordLen int) {
+	if m.End-m.Start < passwordLen {
+		minGuesses := float64(minSubMatchGuessesMultiChar)
+		if m.End-m.Start == 1 {
+			minGuesses = minSubMatchGuessesSingleChar
+		}
+		m.Guesses = math.Max(m.Guesses, minGuesses)
+	}
+	m.Guesses = math.Max(m.Guesses, 1)
+	m.GuessesLog10 = math.Log10(m.Guesses)
+}
@@ -0,0 +1,21 @@
+package strength
+
+import (
+	_ "embed" // for embedding the list of passwords
+	"strings"
+)
+
+// passwords.txt holds the most commonly used passwords, one per line with the
+// most common password first. It is the list of Mark Burnett's top passwords
+// (https://xato.net/passwords/more-top-worst-passwords) as shipped in
+// zxcvbn-go (https://github.com/nbutton23/zxcvbn-go, data/data/Passwords.json)
+// under the MIT license, Copyright (c) Nathan Button.
+//
+//go:embed passwords.txt
+var commonPasswordsTxtRaw string
+
+var (
+	// commonPasswords is a list of the most commonly used passwords, with the
+	// most common password first.
+	commonPasswords = strings.Fields(commonPasswordsTxtRaw)
+)
@@ -0,0 +1,64 @@
+package strength
+
+import (
+	"math"
+)
+
+// repeatMatches returns all the repetitions (like "aaa" or "abcabc") found in
+// the password.
+func (e *estimator) repeatMatches(password []rune) []Match {
+	var rsp []Match
+	for i := 0; i < len(password); {
+		baseLen, count := longestRepeat(password[i:])
+		if count < 2 {
+			i++
+			continue
+		}
+
+		j := i + baseLen*count
+		base := password[i : i+baseLen]
+		rsp = append(rsp, Match{
+			Pattern:     Repeat,
+			Start:       i,
+			End:         j,
+			Token:       string(password[i:j]),
+			Guesses:     math.Pow(10, e.guesses(base)) * float64(count),
+			BaseToken:   string(base),
+			RepeatCount: count,
+		})
+		i = j
+	}
+	return rsp
+}
+
+// longestRepeat returns the length of the base token (and the number of times
+// it repeats) that covers the most of the start of the given runes; ties are
+// broken in favor of the shortest base token.
+func longestRepeat(runes []rune) (int, int) {
+	bestBaseLen, bestCount := 0, 0
+	for baseLen := 1; baseLen*2 <= len(runes); baseLen++ {
+		count := 1
+		for end := baseLen * 2; end <= len(runes); end += baseLen {
+			if !equalRunes(runes[:baseLen], runes[end-baseLen:end]) {
+				break
+			}
+			count++
+		}
+		if count >= 2 && baseLen*count > bestBaseLen*bestCount {
+			bestBaseLen, bestCount = baseLen, count
+		}
+	}
+	return bestBaseLen, bestCount
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
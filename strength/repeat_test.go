@@ -0,0 +1,38 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator_repeatMatches(t *testing.T) {
+	e := NewEstimator().(*estimator)
+
+	matches := e.repeatMatches([]rune("xaaaay abcabcabc"))
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "aaaa", matches[0].Token)
+	assert.Equal(t, "a", matches[0].BaseToken)
+	assert.Equal(t, 4, matches[0].RepeatCount)
+	assert.Equal(t, 1, matches[0].Start)
+	assert.Equal(t, 5, matches[0].End)
+	assert.Equal(t, "abcabcabc", matches[1].Token)
+	assert.Equal(t, "abc", matches[1].BaseToken)
+	assert.Equal(t, 3, matches[1].RepeatCount)
+
+	assert.Empty(t, e.repeatMatches([]rune("abcdef")))
+}
+
+func Test_longestRepeat(t *testing.T) {
+	baseLen, count := longestRepeat([]rune("abababx"))
+	assert.Equal(t, 2, baseLen)
+	assert.Equal(t, 3, count)
+
+	baseLen, count = longestRepeat([]rune("aaaa"))
+	assert.Equal(t, 1, baseLen)
+	assert.Equal(t, 4, count)
+
+	baseLen, count = longestRepeat([]rune("abc"))
+	assert.Equal(t, 0, baseLen)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,54 @@
+package strength
+
+import "github.com/jedib0t/go-passwords/passphrase/dictionaries"
+
+// Rule controls how the Estimator estimates the strength of passwords.
+type Rule func(e *estimator)
+
+// Names of the built-in dictionaries.
+const (
+	dictionaryEnglish    = "english"
+	dictionaryPasswords  = "passwords"
+	dictionaryUserInputs = "user_inputs"
+)
+
+var (
+	basicRules = []Rule{
+		WithRankedDictionary(dictionaryPasswords, commonPasswords),
+		WithDictionary(dictionaryEnglish, dictionaries.English()),
+	}
+)
+
+// WithDictionary adds a list of words (in no particular order) to look for in
+// the passwords.
+func WithDictionary(name string, words []string) Rule {
+	d := newDictionary(name, words)
+
+	return func(e *estimator) {
+		e.dictionaries = append(e.dictionaries, d)
+	}
+}
+
+// WithRankedDictionary adds a list of words sorted by their frequency of use
+// (most common first) to look for in the passwords.
+func WithRankedDictionary(name string, words []string) Rule {
+	d := newRankedDictionary(name, words)
+
+	return func(e *estimator) {
+		e.dictionaries = append(e.dictionaries, d)
+	}
+}
+
+// WithReferenceYear sets the year that dates in the passwords are compared
+// against; defaults to the current year.
+func WithReferenceYear(year int) Rule {
+	return func(e *estimator) {
+		e.referenceYear = year
+	}
+}
+
+// WithUserInputs adds user specific information (like the user name, email
+// address, company name, etc.) to look for in the passwords.
+func WithUserInputs(inputs ...string) Rule {
+	return WithRankedDictionary(dictionaryUserInputs, inputs)
+}
@@ -0,0 +1,88 @@
+package strength
+
+import (
+	"math"
+)
+
+// sequenceSearch holds the state of the search for the most guessable sequence
+// of matches in a password.
+type sequenceSearch struct {
+	// entries[k][l] is the best sequence of l matches covering the first k+1
+	// characters of the password
+	entries [][]sequenceEntry
+}
+
+type sequenceEntry struct {
+	set bool
+	// guesses (in log10) needed to crack the sequence
+	guesses float64
+	// product (in log10) of the guesses of every match in the sequence
+	product float64
+	// match is the last match in the sequence
+	match Match
+}
+
+func newSequenceSearch(n int) *sequenceSearch {
+	s := &sequenceSearch{entries: make([][]sequenceEntry, n)}
+	for k := range s.entries {
+		s.entries[k] = make([]sequenceEntry, n+1)
+	}
+	return s
+}
+
+// bruteForceUpdate evaluates brute-forcing the characters ending at position
+// k, either from the start of the password, or after a non brute-force match.
+func (s *sequenceSearch) bruteForceUpdate(password []rune, k int) {
+	s.update(newBruteForceMatch(password, 0, k+1), 1)
+	for i := 1; i <= k; i++ {
+		m := newBruteForceMatch(password, i, k+1)
+		for l, entry := range s.entries[i-1] {
+			// never have two brute-force matches next to each other
+			if entry.set && entry.match.Pattern != BruteForce {
+				s.update(m, l+1)
+			}
+		}
+	}
+}
+
+// unwind returns the best sequence of matches covering the entire password,
+// along with the guesses (in log10) needed to crack it.
+func (s *sequenceSearch) unwind() ([]Match, float64) {
+	k := len(s.entries) - 1
+	l, guesses := 0, math.Inf(1)
+	for candidate, entry := range s.entries[k] {
+		if entry.set && entry.guesses < guesses {
+			l, guesses = candidate, entry.guesses
+		}
+	}
+
+	rsp := make([]Match, l)
+	for ; k >= 0 && l > 0; l-- {
+		m := s.entries[k][l].match
+		rsp[l-1] = m
+		k = m.Start - 1
+	}
+	return rsp, guesses
+}
+
+// update evaluates the sequence of l matches ending with the given match, and
+// records it if it is better than all the known sequences of at most l
+// matches covering the same characters.
+func (s *sequenceSearch) update(m Match, l int) {
+	k := m.End - 1
+	product := m.GuessesLog10
+	if l > 1 {
+		product += s.entries[m.Start-1][l-1].product
+	}
+	guesses := log10Factorial(l) + product
+	if l > 1 {
+		guesses = log10Add(guesses, float64(l-1)*math.Log10(minGuessesBeforeGrowingSequence))
+	}
+
+	for competingL := 1; competingL <= l; competingL++ {
+		if entry := s.entries[k][competingL]; entry.set && entry.guesses <= guesses {
+			return
+		}
+	}
+	s.entries[k][l] = sequenceEntry{set: true, guesses: guesses, product: product, match: m}
+}
@@ -0,0 +1,66 @@
+package strength
+
+import (
+	"unicode"
+)
+
+const (
+	// maxSequenceDelta is the largest difference between two consecutive
+	// characters that is considered to be a sequence, like "aceg" or "9630".
+	maxSequenceDelta = 5
+)
+
+// sequenceMatches returns all the sequences (like "abcd" or "8642") found in
+// the password.
+func sequenceMatches(password []rune) []Match {
+	if len(password) < 3 {
+		return nil
+	}
+
+	var rsp []Match
+	add := func(i, j int, delta rune) {
+		if j-i >= 3 && delta != 0 && delta >= -maxSequenceDelta && delta <= maxSequenceDelta {
+			m := Match{
+				Pattern:   Sequence,
+				Start:     i,
+				End:       j,
+				Token:     string(password[i:j]),
+				Ascending: delta > 0,
+			}
+			m.Guesses = sequenceGuesses(m)
+			rsp = append(rsp, m)
+		}
+	}
+
+	i, lastDelta := 0, password[1]-password[0]
+	for k := 2; k < len(password); k++ {
+		if delta := password[k] - password[k-1]; delta != lastDelta {
+			add(i, k, lastDelta)
+			i, lastDelta = k-1, delta
+		}
+	}
+	add(i, len(password), lastDelta)
+	return rsp
+}
+
+// sequenceGuesses returns the number of guesses needed to find the sequence.
+func sequenceGuesses(m Match) float64 {
+	token := []rune(m.Token)
+	first := token[0]
+
+	var guesses float64
+	switch {
+	case first == 'a' || first == 'A' || first == 'z' || first == 'Z' || first == '0' || first == '1' || first == '9':
+		// obvious start points
+		guesses = 4
+	case unicode.IsDigit(first):
+		guesses = 10
+	default:
+		// could be any letter (or any other character)
+		guesses = 26
+	}
+	if !m.Ascending {
+		guesses *= 2
+	}
+	return guesses * float64(len(token))
+}
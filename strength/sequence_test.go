@@ -0,0 +1,28 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sequenceMatches(t *testing.T) {
+	matches := sequenceMatches([]rune("abcd x 9753 zzz"))
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "abcd", matches[0].Token)
+	assert.True(t, matches[0].Ascending)
+	assert.Equal(t, 16.0, matches[0].Guesses)
+	assert.Equal(t, "9753", matches[1].Token)
+	assert.False(t, matches[1].Ascending)
+	assert.Equal(t, 32.0, matches[1].Guesses)
+
+	assert.Empty(t, sequenceMatches([]rune("ab")))
+	assert.Empty(t, sequenceMatches([]rune("aqz")))
+}
+
+func Test_sequenceGuesses(t *testing.T) {
+	assert.Equal(t, 12.0, sequenceGuesses(Match{Token: "123", Ascending: true}))
+	assert.Equal(t, 30.0, sequenceGuesses(Match{Token: "345", Ascending: true}))
+	assert.Equal(t, 78.0, sequenceGuesses(Match{Token: "klm", Ascending: true}))
+	assert.Equal(t, 156.0, sequenceGuesses(Match{Token: "mlk", Ascending: false}))
+}
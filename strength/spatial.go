@@ -0,0 +1,191 @@
+package strength
+
+// Directions of movement on a keyboard.
+const (
+	directionLeft = iota
+	directionUpLeft
+	directionUpRight
+	directionRight
+	directionDownRight
+	directionDownLeft
+	numDirections
+)
+
+var (
+	qwerty = newKeyboard(
+		[]string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"},
+		[]string{"~!@#$%^&*()_+", "QWERTYUIOP{}|", "ASDFGHJKL:\"", "ZXCVBNM<>?"},
+		[]int{0, 1, 0, 0},
+	)
+)
+
+// keyboard is a (slanted) keyboard layout.
+type keyboard struct {
+	// avgDegree is the average number of neighbors of a key
+	avgDegree float64
+	// numKeys is the number of keys on the keyboard
+	numKeys   int
+	positions map[rune]keyPosition
+	rows      [][]rune
+	// rowShifts[r] is the number of keys row r is shifted to the left compared
+	// to row r-1
+	rowShifts []int
+}
+
+type keyPosition struct {
+	row     int
+	col     int
+	shifted bool
+}
+
+func newKeyboard(rows []string, rowsShifted []string, rowShifts []int) *keyboard {
+	k := &keyboard{
+		positions: make(map[rune]keyPosition),
+		rowShifts: rowShifts,
+	}
+	for row := range rows {
+		k.rows = append(k.rows, []rune(rows[row]))
+		for col, r := range []rune(rows[row]) {
+			k.positions[r] = keyPosition{row: row, col: col}
+		}
+		for col, r := range []rune(rowsShifted[row]) {
+			k.positions[r] = keyPosition{row: row, col: col, shifted: true}
+		}
+	}
+
+	numNeighbors := 0
+	for row := range k.rows {
+		for col := range k.rows[row] {
+			for direction := 0; direction < numDirections; direction++ {
+				if k.neighbor(keyPosition{row: row, col: col}, direction) != 0 {
+					numNeighbors++
+				}
+			}
+			k.numKeys++
+		}
+	}
+	k.avgDegree = float64(numNeighbors) / float64(k.numKeys)
+	return k
+}
+
+// direction returns the direction of movement from key a to key b, or -1 if
+// they are not next to each other.
+func (k *keyboard) direction(a, b rune) int {
+	posA, okA := k.positions[a]
+	posB, okB := k.positions[b]
+	if !okA || !okB {
+		return -1
+	}
+	posB.shifted = false
+	for direction := 0; direction < numDirections; direction++ {
+		if n := k.neighbor(posA, direction); n != 0 && k.positions[n] == posB {
+			return direction
+		}
+	}
+	return -1
+}
+
+// isShifted returns true if the character needs the Shift key to be typed.
+func (k *keyboard) isShifted(r rune) bool {
+	return k.positions[r].shifted
+}
+
+// neighbor returns the (un-shifted) key next to the given key in the given
+// direction, or 0 if there is none.
+func (k *keyboard) neighbor(pos keyPosition, direction int) rune {
+	row, col := pos.row, pos.col
+	switch direction {
+	case directionLeft:
+		col--
+	case directionRight:
+		col++
+	case directionUpLeft, directionUpRight:
+		if row == 0 {
+			return 0
+		}
+		col += k.rowShifts[row]
+		if direction == directionUpRight {
+			col++
+		}
+		row--
+	case directionDownLeft, directionDownRight:
+		if row == len(k.rows)-1 {
+			return 0
+		}
+		col -= k.rowShifts[row+1]
+		if direction == directionDownLeft {
+			col--
+		}
+		row++
+	}
+	if col < 0 || col >= len(k.rows[row]) {
+		return 0
+	}
+	return k.rows[row][col]
+}
+
+// spatialMatches returns all the keyboard walks (like "qwerty" or "zxcvfr")
+// found in the password.
+func spatialMatches(password []rune) []Match {
+	var rsp []Match
+	for i := 0; i < len(password)-1; {
+		j, turns, lastDirection, shiftedCount := i+1, 0, -1, 0
+		if qwerty.isShifted(password[i]) {
+			shiftedCount++
+		}
+		for ; j < len(password); j++ {
+			direction := qwerty.direction(password[j-1], password[j])
+			if direction < 0 {
+				break
+			}
+			if direction != lastDirection {
+				turns++
+				lastDirection = direction
+			}
+			if qwerty.isShifted(password[j]) {
+				shiftedCount++
+			}
+		}
+
+		if j-i > 2 {
+			m := Match{
+				Pattern:      Spatial,
+				Start:        i,
+				End:          j,
+				Token:        string(password[i:j]),
+				Turns:        turns,
+				ShiftedCount: shiftedCount,
+			}
+			m.Guesses = spatialGuesses(m, qwerty)
+			rsp = append(rsp, m)
+		}
+		i = j
+	}
+	return rsp
+}
+
+// spatialGuesses returns the number of guesses needed to find the keyboard
+// walk; it counts all the walks of the same or lesser length and turns.
+func spatialGuesses(m Match, k *keyboard) float64 {
+	length := m.End - m.Start
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= min(m.Turns, i-1); j++ {
+			possibleWalks := float64(k.numKeys)
+			for idx := 0; idx < j; idx++ {
+				possibleWalks *= k.avgDegree
+			}
+			guesses += binomial(i-1, j-1) * possibleWalks
+		}
+	}
+
+	// add the variations of using the Shift key
+	if shifted, unshifted := m.ShiftedCount, length-m.ShiftedCount; shifted > 0 {
+		if unshifted == 0 {
+			guesses *= 2
+		} else {
+			guesses *= variations(shifted, unshifted)
+		}
+	}
+	return guesses
+}
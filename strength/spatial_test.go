@@ -0,0 +1,46 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyboard(t *testing.T) {
+	assert.Equal(t, 47, qwerty.numKeys)
+	assert.InDelta(t, 4.6, qwerty.avgDegree, 0.1)
+
+	assert.Equal(t, directionRight, qwerty.direction('q', 'w'))
+	assert.Equal(t, directionLeft, qwerty.direction('w', 'q'))
+	assert.Equal(t, directionUpLeft, qwerty.direction('q', '1'))
+	assert.Equal(t, directionUpRight, qwerty.direction('q', '2'))
+	assert.Equal(t, directionDownRight, qwerty.direction('q', 'a'))
+	assert.Equal(t, directionDownLeft, qwerty.direction('w', 'a'))
+	assert.Equal(t, directionUpLeft, qwerty.direction('a', 'Q'))
+	assert.Equal(t, -1, qwerty.direction('q', 'q'))
+	assert.Equal(t, -1, qwerty.direction('q', 'p'))
+	assert.Equal(t, -1, qwerty.direction('q', 'é'))
+
+	assert.True(t, qwerty.isShifted('Q'))
+	assert.True(t, qwerty.isShifted('!'))
+	assert.False(t, qwerty.isShifted('q'))
+	assert.False(t, qwerty.isShifted('é'))
+}
+
+func Test_spatialMatches(t *testing.T) {
+	matches := spatialMatches([]rune("xqwertyx zxcvfr QWE as"))
+	assert.Len(t, matches, 3)
+	assert.Equal(t, "qwerty", matches[0].Token)
+	assert.Equal(t, 1, matches[0].Turns)
+	assert.Equal(t, 0, matches[0].ShiftedCount)
+	assert.Equal(t, "zxcvfr", matches[1].Token)
+	assert.Equal(t, 2, matches[1].Turns)
+	assert.Equal(t, "QWE", matches[2].Token)
+	assert.Equal(t, 3, matches[2].ShiftedCount)
+
+	// more turns and shifted characters need more guesses
+	assert.Less(t, spatialGuesses(Match{Start: 0, End: 6, Turns: 1}, qwerty),
+		spatialGuesses(Match{Start: 0, End: 6, Turns: 3}, qwerty))
+	assert.Equal(t, 2*spatialGuesses(Match{Start: 0, End: 3, Turns: 1}, qwerty),
+		spatialGuesses(matches[2], qwerty))
+}
@@ -0,0 +1,50 @@
+package strength
+
+import (
+	"math"
+	"unicode"
+)
+
+// binomial returns the number of ways to choose k items from n items.
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	rsp := 1.0
+	for idx := 1; idx <= k; idx++ {
+		rsp = rsp * float64(n-k+idx) / float64(idx)
+	}
+	return rsp
+}
+
+// log10Add returns log10(10^a + 10^b) without overflowing.
+func log10Add(a, b float64) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	return a + math.Log10(1+math.Pow(10, b-a))
+}
+
+// log10Factorial returns log10(n!).
+func log10Factorial(n int) float64 {
+	lgamma, _ := math.Lgamma(float64(n + 1))
+	return lgamma / math.Ln10
+}
+
+// toLower returns a lower-cased copy of the given runes.
+func toLower(runes []rune) []rune {
+	rsp := make([]rune, len(runes))
+	for idx, r := range runes {
+		rsp[idx] = unicode.ToLower(r)
+	}
+	return rsp
+}
+
+// reverse returns a reversed copy of the given runes.
+func reverse(runes []rune) []rune {
+	rsp := make([]rune, len(runes))
+	for idx, r := range runes {
+		rsp[len(runes)-1-idx] = r
+	}
+	return rsp
+}